@@ -0,0 +1,148 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lgboyce/leakyrepo/scanner"
+)
+
+// DefaultFileName is the file written by "leakyrepo baseline create"
+const DefaultFileName = ".leakyrepo-baseline.json"
+
+// Baseline records known findings so that scans only fail on new ones
+type Baseline struct {
+	// Version is the baseline file format version
+	Version int `json:"version"`
+	// CreatedAt is when the baseline was generated
+	CreatedAt time.Time `json:"created_at"`
+	// Findings are the known findings, one entry per fingerprint
+	Findings []Entry `json:"findings"`
+}
+
+// Entry is a known finding, keyed by its scanner.Result fingerprint
+type Entry struct {
+	// Fingerprint identifies the finding across runs
+	Fingerprint string `json:"fingerprint"`
+	// File is the path of the file, relative to the baseline root
+	File string `json:"file"`
+	// Line is where the finding was when the baseline was created (informational)
+	Line int `json:"line"`
+	// RuleID is the rule that matched (empty for entropy-based detection)
+	RuleID string `json:"rule_id,omitempty"`
+	// Severity indicates the severity level
+	Severity string `json:"severity"`
+	// Match is the masked matched string
+	Match string `json:"match"`
+}
+
+// New creates a baseline from scan results. Paths are stored relative to root.
+func New(results []scanner.Result, root string) *Baseline {
+	b := &Baseline{
+		Version:   1,
+		CreatedAt: time.Now().UTC(),
+		Findings:  []Entry{},
+	}
+
+	seen := make(map[string]bool)
+	for _, result := range results {
+		if seen[result.Fingerprint] {
+			continue
+		}
+		seen[result.Fingerprint] = true
+		b.Findings = append(b.Findings, Entry{
+			Fingerprint: result.Fingerprint,
+			File:        relativePath(result.File, root),
+			Line:        result.Line,
+			RuleID:      result.RuleID,
+			Severity:    result.Severity,
+			Match:       result.Match,
+		})
+	}
+
+	sort.Slice(b.Findings, func(i, j int) bool {
+		if b.Findings[i].File != b.Findings[j].File {
+			return b.Findings[i].File < b.Findings[j].File
+		}
+		return b.Findings[i].Line < b.Findings[j].Line
+	})
+
+	return b
+}
+
+// Load reads a baseline file
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline file: %w", err)
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline file: %w", err)
+	}
+
+	return &b, nil
+}
+
+// Save writes the baseline to a file
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baseline: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline file: %w", err)
+	}
+
+	return nil
+}
+
+// Filter splits results into new findings and findings already in the baseline.
+// Known findings are returned with their Suppression set to "baseline". Baseline
+// entries for scanned files that no longer match any result are returned as stale.
+// When complete is set, scannedFiles covers the whole repository, so entries for
+// files outside it (deleted or renamed) are stale too.
+func (b *Baseline) Filter(results []scanner.Result, root string, scannedFiles []string, complete bool) (newResults, known []scanner.Result, stale []Entry) {
+	entries := make(map[string]bool, len(b.Findings))
+	for _, entry := range b.Findings {
+		entries[entry.Fingerprint] = true
+	}
+
+	matched := make(map[string]bool)
+	for _, result := range results {
+		if entries[result.Fingerprint] {
+			matched[result.Fingerprint] = true
+			result.Suppression = "baseline"
+			known = append(known, result)
+			continue
+		}
+		newResults = append(newResults, result)
+	}
+
+	scanned := make(map[string]bool, len(scannedFiles))
+	for _, file := range scannedFiles {
+		scanned[relativePath(file, root)] = true
+	}
+	for _, entry := range b.Findings {
+		if (scanned[entry.File] || complete) && !matched[entry.Fingerprint] {
+			stale = append(stale, entry)
+		}
+	}
+
+	return newResults, known, stale
+}
+
+// relativePath returns path relative to root using forward slashes
+func relativePath(path, root string) string {
+	if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(path)
+}
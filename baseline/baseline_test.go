@@ -0,0 +1,55 @@
+package baseline
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/lgboyce/leakyrepo/scanner"
+)
+
+func TestBaseline_Filter(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "config", "app.env")
+	other := filepath.Join(root, "other.env")
+
+	known := scanner.Result{File: file, Line: 3, RuleID: "aws_access_key", Fingerprint: "f1"}
+	removed := scanner.Result{File: file, Line: 4, RuleID: "aws_access_key", Fingerprint: "f2"}
+	unscanned := scanner.Result{File: other, Line: 1, DetectionType: "entropy", Fingerprint: "f3"}
+
+	b := New([]scanner.Result{known, removed, unscanned}, root)
+	if len(b.Findings) != 3 {
+		t.Fatalf("Expected 3 baseline entries, got %d", len(b.Findings))
+	}
+
+	path := filepath.Join(root, DefaultFileName)
+	if err := b.Save(path); err != nil {
+		t.Fatalf("Failed to save baseline: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load baseline: %v", err)
+	}
+
+	// The known finding moved to another line, and a new one appeared
+	moved := known
+	moved.Line = 10
+	added := scanner.Result{File: file, Line: 11, RuleID: "aws_access_key", Fingerprint: "f4"}
+
+	newResults, knownResults, stale := loaded.Filter([]scanner.Result{moved, added}, root, []string{file}, false)
+
+	if len(newResults) != 1 || newResults[0].Line != 11 {
+		t.Errorf("Expected only the added finding to be new, got %+v", newResults)
+	}
+	if len(knownResults) != 1 || knownResults[0].Suppression != "baseline" {
+		t.Errorf("Expected the moved finding to be suppressed by the baseline, got %+v", knownResults)
+	}
+	if len(stale) != 1 || stale[0].Fingerprint != removed.Fingerprint {
+		t.Errorf("Expected the removed finding to be stale, got %+v", stale)
+	}
+
+	// When the whole repository was scanned, entries for files that are gone are stale
+	_, _, stale = loaded.Filter([]scanner.Result{moved}, root, []string{file}, true)
+	if len(stale) != 2 || stale[0].Fingerprint != removed.Fingerprint || stale[1].Fingerprint != unscanned.Fingerprint {
+		t.Errorf("Expected the removed finding and the deleted file's finding to be stale, got %+v", stale)
+	}
+}
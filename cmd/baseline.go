@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/lgboyce/leakyrepo/baseline"
+	"github.com/lgboyce/leakyrepo/ignore"
+	"github.com/spf13/cobra"
+)
+
+var baselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Manage the baseline of known findings",
+	Long: `A baseline records the findings that already exist in a repository, so that
+'leakyrepo scan --baseline <file>' only fails on new findings.`,
+}
+
+var baselineCreateCmd = &cobra.Command{
+	Use:   "create [files...]",
+	Short: "Create a baseline file from the current findings",
+	Long: `Scans all tracked files (or the given files) and writes the findings to
+.leakyrepo-baseline.json, keyed by a fingerprint that does not depend on line numbers.
+
+Examples:
+  leakyrepo baseline create
+  leakyrepo scan --all --baseline .leakyrepo-baseline.json
+`,
+	RunE: runBaselineCreate,
+}
+
+var baselineOutput string
+
+func init() {
+	baselineCreateCmd.Flags().StringVarP(&baselineOutput, "output", "o", baseline.DefaultFileName, "Path of the baseline file to write")
+	baselineCmd.AddCommand(baselineCreateCmd)
+	// Note: Command is added in root.go to avoid duplicate registration
+}
+
+func runBaselineCreate(cmd *cobra.Command, args []string) error {
+	workDir := getWorkingDir()
+
+	cfg, _, err := loadConfig(workDir)
+	if err != nil {
+		return err
+	}
+
+	ignorePatterns, err := ignore.LoadIgnorePatterns(filepath.Join(workDir, ".leakyrepoignore"))
+	if err != nil {
+		return configError(fmt.Errorf("failed to load ignore patterns: %w", err))
+	}
+
+	root := getRepoRoot(workDir)
+	scnr, err := newScanner(cfg, ignorePatterns, root)
+	if err != nil {
+		return err
+	}
+
+	// Baselines cover the whole repository unless files are given
+	files, err := collectFiles(workDir, args, true)
+	if err != nil {
+		return runtimeError(err)
+	}
+
+	results, failed := scanFiles(scnr, files)
+	if failed > 0 {
+		return runtimeError(fmt.Errorf("%d file(s) could not be scanned; not writing an incomplete baseline", failed))
+	}
+	b := baseline.New(results, root)
+	if err := b.Save(baselineOutput); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Wrote %d finding(s) to %s\n", len(b.Findings), baselineOutput)
+	return nil
+}
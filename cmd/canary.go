@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lgboyce/leakyrepo/ignore"
+	"github.com/lgboyce/leakyrepo/scanner"
+	"github.com/spf13/cobra"
+)
+
+var canaryCmd = &cobra.Command{
+	Use:   "canary",
+	Short: "Manage honeytokens planted in the repository",
+	Long: `Honeytokens (canary credentials) are declared under 'honeytokens' in
+.leakyrepo.yml. Scans classify them as canaries and do not fail on them.`,
+}
+
+var canaryCheckCmd = &cobra.Command{
+	Use:   "check [files...]",
+	Short: "Report honeytokens missing from their expected locations",
+	Long: `Scans all tracked files (or the given files) and reports, for each declared
+honeytoken, where it was found and which of its expected locations no longer
+contain it. Exits with status 1 if any honeytoken is missing.
+
+Examples:
+  leakyrepo canary check
+`,
+	RunE: runCanaryCheck,
+}
+
+func init() {
+	canaryCmd.AddCommand(canaryCheckCmd)
+	// Note: Command is added in root.go to avoid duplicate registration
+}
+
+func runCanaryCheck(cmd *cobra.Command, args []string) error {
+	workDir := getWorkingDir()
+
+	cfg, _, err := loadConfig(workDir)
+	if err != nil {
+		return err
+	}
+	if len(cfg.Honeytokens) == 0 {
+		fmt.Println("No honeytokens declared in configuration.")
+		return nil
+	}
+
+	ignorePatterns, err := ignore.LoadIgnorePatterns(filepath.Join(workDir, ".leakyrepoignore"))
+	if err != nil {
+		return configError(fmt.Errorf("failed to load ignore patterns: %w", err))
+	}
+
+	// Locations are relative to the repository root, like fingerprints
+	root := getRepoRoot(workDir)
+	scnr, err := newScanner(cfg, ignorePatterns, root)
+	if err != nil {
+		return err
+	}
+
+	files, err := collectFiles(workDir, args, true)
+	if err != nil {
+		return runtimeError(err)
+	}
+
+	results, _ := scanFiles(scnr, files)
+	missing := 0
+	for _, status := range scanner.CheckCanaries(cfg.Honeytokens, results, root) {
+		if len(status.Missing) == 0 {
+			fmt.Printf("✓ %s found in %s\n", status.Name, strings.Join(status.Found, ", "))
+			continue
+		}
+
+		missing++
+		if len(status.Found) == 0 {
+			fmt.Printf("✗ %s not found\n", status.Name)
+		} else {
+			fmt.Printf("✗ %s found in %s\n", status.Name, strings.Join(status.Found, ", "))
+		}
+		for _, location := range status.Missing {
+			if location != "*" {
+				fmt.Printf("   missing from %s\n", location)
+			}
+		}
+	}
+
+	if missing > 0 {
+		fmt.Printf("\n%d honeytoken(s) missing from where they should be planted\n", missing)
+		os.Exit(exitFindings)
+	}
+	return nil
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/lgboyce/leakyrepo/config"
+	"github.com/lgboyce/leakyrepo/scanner"
 	"github.com/spf13/cobra"
 )
 
@@ -18,23 +21,34 @@ Examples:
   leakyrepo ignore scripts/test-homebrew.sh
   leakyrepo ignore --pattern "*test*.sh"
   leakyrepo ignore --file scripts/
+  leakyrepo ignore --finding config/app.env:12
+
+With --finding, the secrets detected on that line are added to allowlist.hashes
+in .leakyrepo.yml as SHA-256 digests, so the plaintext value is never committed.
 `,
+	Args: usageArgs(cobra.MaximumNArgs(1)),
 	RunE: runIgnore,
 }
 
 var (
 	ignorePattern string
 	ignoreFile    string
+	ignoreFinding string
 )
 
 func init() {
 	ignoreCmd.Flags().StringVar(&ignorePattern, "pattern", "", "Pattern to ignore (e.g., '*test*.sh')")
 	ignoreCmd.Flags().StringVar(&ignoreFile, "file", "", "File or directory to ignore")
+	ignoreCmd.Flags().StringVar(&ignoreFinding, "finding", "", "Allowlist the secrets found at <file>:<line> by hash")
 	// Note: Command is added in root.go to avoid duplicate registration
 }
 
 func runIgnore(cmd *cobra.Command, args []string) error {
 	workDir := getWorkingDir()
+	if ignoreFinding != "" {
+		return ignoreFindingByHash(workDir, ignoreFinding)
+	}
+
 	ignorePath := filepath.Join(workDir, ".leakyrepoignore")
 
 	// Determine what to ignore
@@ -46,7 +60,7 @@ func runIgnore(cmd *cobra.Command, args []string) error {
 	} else if len(args) > 0 {
 		pattern = args[0]
 	} else {
-		return fmt.Errorf("specify a file, pattern, or use --file/--pattern flags")
+		return configError(fmt.Errorf("specify a file, pattern, or use --file/--pattern flags"))
 	}
 
 	// Read existing ignore file
@@ -96,3 +110,82 @@ func runIgnore(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// ignoreFindingByHash adds the SHA-256 digests of the secrets found at file:line
+// to allowlist.hashes in .leakyrepo.yml
+func ignoreFindingByHash(workDir, finding string) error {
+	sep := strings.LastIndex(finding, ":")
+	if sep <= 0 {
+		return configError(fmt.Errorf("invalid finding %q: expected <file>:<line>", finding))
+	}
+	lineNum, err := strconv.Atoi(finding[sep+1:])
+	if err != nil || lineNum < 1 {
+		return configError(fmt.Errorf("invalid line number in finding %q", finding))
+	}
+	filePath, err := filepath.Abs(finding[:sep])
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path for %s: %w", finding[:sep], err)
+	}
+
+	cfg, configPath, err := loadConfig(workDir)
+	if err != nil {
+		return err
+	}
+	if configPath == "" {
+		configPath = filepath.Join(workDir, ".leakyrepo.yml")
+	}
+
+	// Scan the file without ignore patterns or hashes so the finding is always reported
+	scanCfg := *cfg
+	scanCfg.Allowlist.Hashes = nil
+	scnr, err := scanner.NewScanner(&scanCfg, nil)
+	if err != nil {
+		return configError(fmt.Errorf("failed to create scanner: %w", err))
+	}
+	results, err := scnr.ScanFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool)
+	for _, hash := range cfg.Allowlist.Hashes {
+		existing[strings.ToLower(hash)] = true
+	}
+
+	found := 0
+	var added []string
+	for _, result := range results {
+		if result.Line != lineNum || result.SecretHash == "" {
+			continue
+		}
+		found++
+		if existing[result.SecretHash] {
+			continue
+		}
+		added = append(added, result.SecretHash)
+		existing[result.SecretHash] = true
+	}
+
+	if found == 0 {
+		return fmt.Errorf("no findings at %s", finding)
+	}
+	if len(added) == 0 {
+		fmt.Printf("Finding(s) at %s already allowlisted\n", finding)
+		return nil
+	}
+
+	if _, err := os.Stat(configPath); err == nil {
+		// Edit the existing file in place so its comments and layout are kept
+		if err := config.AddAllowlistHashes(configPath, added); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+	} else {
+		// No config file yet: write the defaults along with the hashes
+		cfg.Allowlist.Hashes = append(cfg.Allowlist.Hashes, added...)
+		if err := config.SaveConfig(cfg, configPath); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+	}
+
+	fmt.Printf("✓ Added %d hash(es) to allowlist in %s\n", len(added), configPath)
+	return nil
+}
@@ -14,6 +14,7 @@ var initCmd = &cobra.Command{
 	Short: "Initialize LeakyRepo configuration",
 	Long: `Creates a default .leakyrepo.yml configuration file in the current directory.
 This file contains regex rules for common secret patterns and entropy thresholds.`,
+	Args: usageArgs(cobra.NoArgs),
 	RunE: runInit,
 }
 
@@ -23,7 +24,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	// Check if config already exists
 	if _, err := os.Stat(configPath); err == nil {
-		return fmt.Errorf("configuration file already exists: %s\nUse --force to overwrite", configPath)
+		return configError(fmt.Errorf("configuration file already exists: %s\nUse --force to overwrite", configPath))
 	}
 
 	// Create default config
@@ -14,6 +14,7 @@ var installHookCmd = &cobra.Command{
 	Short: "Install Git pre-commit hook",
 	Long: `Installs a Git pre-commit hook that will automatically scan staged files
 for secrets before allowing a commit. The commit will be blocked if secrets are detected.`,
+	Args: usageArgs(cobra.NoArgs),
 	RunE: runInstallHook,
 }
 
@@ -79,14 +80,25 @@ func runInstallHook(cmd *cobra.Command, args []string) error {
 
 # Exit with the same code as leakyrepo
 exit_code=$?
-if [ $exit_code -ne 0 ]; then
+case $exit_code in
+0)
+    ;;
+1)
     echo ""
     echo "❌ Commit blocked: secrets detected in staged files!"
     echo "Review the findings above and remove secrets before committing."
-    exit $exit_code
-fi
+    ;;
+2)
+    echo ""
+    echo "❌ Commit blocked: leakyrepo configuration or usage error (see above)."
+    ;;
+*)
+    echo ""
+    echo "❌ Commit blocked: leakyrepo failed to run (exit code $exit_code, see above)."
+    ;;
+esac
 
-exit 0
+exit $exit_code
 `, binaryName)
 
 	// Write hook script
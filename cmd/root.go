@@ -1,27 +1,100 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime/debug"
+	"strings"
 
+	"github.com/lgboyce/leakyrepo/config"
+	"github.com/lgboyce/leakyrepo/git"
 	"github.com/spf13/cobra"
 )
 
+// Exit codes, so that CI can tell findings from a broken run
+const (
+	exitFindings     = 1
+	exitConfigError  = 2
+	exitRuntimeError = 3
+)
+
+// exitError is an error that exits with a specific code
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// configError marks an error in the configuration, flags or arguments
+func configError(err error) error {
+	return &exitError{code: exitConfigError, err: err}
+}
+
+// usageError marks a command-line usage error, pointing at the command's help
+func usageError(cmd *cobra.Command, err error) error {
+	return configError(fmt.Errorf("%w\nRun '%s --help' for usage", err, cmd.CommandPath()))
+}
+
+// usageArgs wraps a cobra argument validator so that its errors are usage errors
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return usageError(cmd, err)
+		}
+		return nil
+	}
+}
+
+// runtimeError marks an error while running, such as a failing git command
+func runtimeError(err error) error {
+	return &exitError{code: exitRuntimeError, err: err}
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "leakyrepo",
 	Short: "LeakyRepo - A secrets detection tool",
 	Long: `LeakyRepo is a secrets detection tool that combines regex-based 
 pattern matching with entropy-based detection to catch API keys, tokens, 
-and credentials before they're committed to version control.`,
+and credentials before they're committed to version control.
+
+Exit codes: 0 no blocking findings, 1 findings, 2 configuration or usage
+error, 3 runtime or git error.`,
+	SilenceErrors: true,
+	SilenceUsage:  true,
 }
 
 // Execute runs the root command
 func Execute() {
+	// A crash is a broken run, not a configuration error (Go exits 2 on panics)
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "Error: internal error: %v\n%s", r, debug.Stack())
+			os.Exit(exitRuntimeError)
+		}
+	}()
+
+	rootCmd.SetFlagErrorFunc(usageError)
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitCode(err))
+	}
+}
+
+// exitCode returns the exit code for an error returned by a command
+func exitCode(err error) int {
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
 	}
+	// Cobra reports unknown subcommands before any command runs
+	if strings.HasPrefix(err.Error(), "unknown command ") {
+		return exitConfigError
+	}
+	return exitRuntimeError
 }
 
 func init() {
@@ -29,6 +102,8 @@ func init() {
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(installHookCmd)
 	rootCmd.AddCommand(ignoreCmd)
+	rootCmd.AddCommand(baselineCmd)
+	rootCmd.AddCommand(canaryCmd)
 }
 
 // getWorkingDir returns the current working directory
@@ -36,7 +111,16 @@ func getWorkingDir() string {
 	dir, err := os.Getwd()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error getting working directory: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitRuntimeError)
+	}
+	return dir
+}
+
+// getRepoRoot returns the root of the git repository containing dir, or dir itself
+// outside a repository, so that fingerprints do not depend on where a scan runs
+func getRepoRoot(dir string) string {
+	if root, err := git.GetRepoRoot(dir); err == nil {
+		return root
 	}
 	return dir
 }
@@ -61,3 +145,19 @@ func findConfigPath(startDir string) (string, error) {
 	return "", fmt.Errorf("config file .leakyrepo.yml not found")
 }
 
+// loadConfig loads .leakyrepo.yml from startDir or its parents, falling back to the
+// default configuration. The returned path is empty when no config file was found.
+func loadConfig(startDir string) (*config.Config, string, error) {
+	configPath, err := findConfigPath(startDir)
+	if err != nil {
+		// No config found, use default configuration
+		return config.DefaultConfig(), "", nil
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return nil, "", configError(fmt.Errorf("failed to load config: %w", err))
+	}
+
+	return cfg, configPath, nil
+}
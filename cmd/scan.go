@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
+	"github.com/lgboyce/leakyrepo/baseline"
 	"github.com/lgboyce/leakyrepo/config"
 	"github.com/lgboyce/leakyrepo/git"
 	"github.com/lgboyce/leakyrepo/ignore"
@@ -14,10 +17,21 @@ import (
 )
 
 var (
-	jsonOutput string
-	explain    bool
-	interactive bool
-	scanAll   bool
+	jsonOutput      string
+	explain         bool
+	interactive     bool
+	scanAll         bool
+	noInlineIgnores bool
+	scanGenerated   bool
+	ruleIDs         []string
+	excludeRuleIDs  []string
+	ruleTags        []string
+	excludeRuleTags []string
+	noEntropy       bool
+	failOn          string
+	baselinePath    string
+	minConfidence   float64
+	sortBy          string
 )
 
 var scanCmd = &cobra.Command{
@@ -33,144 +47,228 @@ func init() {
 	scanCmd.Flags().BoolVar(&explain, "explain", false, "Show explanation for each detected secret")
 	scanCmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Interactive mode: prompt to ignore false positives")
 	scanCmd.Flags().BoolVar(&scanAll, "all", false, "Scan all tracked files in the repository (default: scan staged files)")
+	scanCmd.Flags().BoolVar(&noInlineIgnores, "no-inline-ignores", false, "Ignore leakyrepo:allow comments in scanned files (useful in CI)")
+	scanCmd.Flags().StringVar(&baselinePath, "baseline", "", "Baseline file of known findings to suppress (see 'leakyrepo baseline create')")
+	scanCmd.Flags().BoolVar(&scanGenerated, "scan-generated", false, "Scan lockfiles, source maps, minified and generated files, which are skipped by default")
+	scanCmd.Flags().StringSliceVar(&ruleIDs, "rules", nil, "Only run the rules and built-in detectors with these IDs (comma-separated)")
+	scanCmd.Flags().StringSliceVar(&excludeRuleIDs, "exclude-rules", nil, "Skip the rules and built-in detectors with these IDs (comma-separated)")
+	scanCmd.Flags().StringSliceVar(&ruleTags, "tags", nil, "Only run the rules and built-in detectors with these tags (comma-separated)")
+	scanCmd.Flags().StringSliceVar(&excludeRuleTags, "exclude-tags", nil, "Skip the rules and built-in detectors with these tags (comma-separated)")
+	scanCmd.Flags().BoolVar(&noEntropy, "no-entropy", false, "Turn off high-entropy string detection")
+	scanCmd.Flags().StringVar(&failOn, "fail-on", "", "Lowest severity that fails the scan: low, medium, high or critical (default: fail_on from config, or low)")
+	scanCmd.Flags().Float64Var(&minConfidence, "min-confidence", 0, "Only report findings with at least this confidence (0-1)")
+	scanCmd.Flags().StringVar(&sortBy, "sort", "file", "Order of findings: file (scan order) or confidence (most likely first)")
 }
 
-func runScan(cmd *cobra.Command, args []string) error {
-	workDir := getWorkingDir()
-
-	// Find and load config, or use default if not found
-	var cfg *config.Config
-	configPath, err := findConfigPath(workDir)
-	if err != nil {
-		// No config found, use default configuration
-		cfg = config.DefaultConfig()
-	} else {
-		cfg, err = config.LoadConfig(configPath)
-		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
-		}
-	}
-
-	// Load ignore patterns
-	ignorePath := filepath.Join(workDir, ".leakyrepoignore")
-	ignorePatterns, err := ignore.LoadIgnorePatterns(ignorePath)
-	if err != nil {
-		return fmt.Errorf("failed to load ignore patterns: %w", err)
-	}
-
-	// Create scanner
-	scnr, err := scanner.NewScanner(cfg, ignorePatterns)
-	if err != nil {
-		return fmt.Errorf("failed to create scanner: %w", err)
-	}
-
-	// Determine files to scan
-	var filesToScan []string
+// collectFiles resolves the files to scan: the given arguments, or else the staged
+// (or, with all set, tracked) files of the git repository. It prints a message and
+// returns no files when there is nothing to scan.
+func collectFiles(workDir string, args []string, all bool) ([]string, error) {
 	if len(args) > 0 {
 		// Use files provided as arguments
+		var files []string
 		for _, arg := range args {
 			absPath, err := filepath.Abs(arg)
 			if err != nil {
-				return fmt.Errorf("failed to get absolute path for %s: %w", arg, err)
+				return nil, fmt.Errorf("failed to get absolute path for %s: %w", arg, err)
 			}
 			if _, err := os.Stat(absPath); err != nil {
-				return fmt.Errorf("file not found: %s", arg)
+				return nil, fmt.Errorf("file not found: %s", arg)
 			}
-			filesToScan = append(filesToScan, absPath)
+			files = append(files, absPath)
 		}
-	} else {
-		// Get files from git
-		repoRoot, err := git.GetRepoRoot(workDir)
+		return files, nil
+	}
+
+	// Get files from git
+	repoRoot, err := git.GetRepoRoot(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find git repository: %w\nSpecify files to scan or run from within a git repository", err)
+	}
+
+	if all {
+		// Get all tracked files
+		trackedFiles, err := git.GetAllTrackedFiles(repoRoot)
 		if err != nil {
-			return fmt.Errorf("failed to find git repository: %w\nSpecify files to scan or run from within a git repository", err)
+			return nil, fmt.Errorf("failed to get tracked files: %w", err)
 		}
 
-		if scanAll {
-			// Get all tracked files
-			trackedFiles, err := git.GetAllTrackedFiles(repoRoot)
-			if err != nil {
-				return fmt.Errorf("failed to get tracked files: %w", err)
-			}
-
-			if len(trackedFiles) == 0 {
-				fmt.Println("No tracked files in repository.")
-				return nil
-			}
+		if len(trackedFiles) == 0 {
+			fmt.Println("No tracked files in repository.")
+		}
+		return trackedFiles, nil
+	}
 
-			filesToScan = trackedFiles
-		} else {
-			// Get staged files from git
-			stagedFiles, err := git.GetStagedFiles(repoRoot)
-			if err != nil {
-				return fmt.Errorf("failed to get staged files: %w", err)
-			}
+	// Get staged files from git
+	stagedFiles, err := git.GetStagedFiles(repoRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staged files: %w", err)
+	}
 
-			if len(stagedFiles) == 0 {
-				fmt.Println("No files staged for commit.")
-				return nil
-			}
+	if len(stagedFiles) == 0 {
+		fmt.Println("No files staged for commit.")
+	}
+	return stagedFiles, nil
+}
 
-			filesToScan = stagedFiles
-		}
+// newScanner creates a scanner using the run options from the command-line flags,
+// with paths relative to root
+func newScanner(cfg *config.Config, ignorePatterns []string, root string) (*scanner.Scanner, error) {
+	scnr, err := scanner.NewScannerWithOptions(cfg, ignorePatterns, scanner.Options{
+		NoInlineIgnores: noInlineIgnores,
+		ScanGenerated:   scanGenerated,
+		Rules:           ruleIDs,
+		ExcludeRules:    excludeRuleIDs,
+		Tags:            ruleTags,
+		ExcludeTags:     excludeRuleTags,
+		NoEntropy:       noEntropy,
+		Root:            root,
+	})
+	if err != nil {
+		return nil, configError(fmt.Errorf("failed to create scanner: %w", err))
 	}
+	return scnr, nil
+}
 
-	// Scan files
+// scanFiles scans each file, printing a warning for files that cannot be read, and
+// returns the findings and the number of files that could not be scanned
+func scanFiles(scnr *scanner.Scanner, files []string) ([]scanner.Result, int) {
 	var allResults []scanner.Result
-	for _, file := range filesToScan {
+	failed := 0
+	for _, file := range files {
 		results, err := scnr.ScanFile(file)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to scan %s: %v\n", file, err)
+			failed++
 			continue
 		}
 		allResults = append(allResults, results...)
 	}
+	return allResults, failed
+}
+
+func runScan(cmd *cobra.Command, args []string) error {
+	workDir := getWorkingDir()
+	root := getRepoRoot(workDir)
+
+	if minConfidence < 0 || minConfidence > 1 {
+		return configError(fmt.Errorf("--min-confidence must be between 0 and 1"))
+	}
+	if sortBy != "file" && sortBy != "confidence" {
+		return configError(fmt.Errorf("invalid --sort value %q (expected file or confidence)", sortBy))
+	}
+
+	// Find and load config, or use default if not found
+	cfg, _, err := loadConfig(workDir)
+	if err != nil {
+		return err
+	}
+	if failOn != "" {
+		cfg.FailOn = failOn
+	}
+	if cfg.FailOn != "" && !scanner.IsSeverity(cfg.FailOn) {
+		return configError(fmt.Errorf("invalid fail-on severity %q (expected low, medium, high or critical)", cfg.FailOn))
+	}
+
+	// Load ignore patterns
+	ignorePath := filepath.Join(workDir, ".leakyrepoignore")
+	ignorePatterns, err := ignore.LoadIgnorePatterns(ignorePath)
+	if err != nil {
+		return configError(fmt.Errorf("failed to load ignore patterns: %w", err))
+	}
+
+	// Create scanner
+	scnr, err := newScanner(cfg, ignorePatterns, root)
+	if err != nil {
+		return err
+	}
+
+	// Determine files to scan
+	filesToScan, err := collectFiles(workDir, args, scanAll)
+	if err != nil {
+		return runtimeError(err)
+	}
+	// A scan of every tracked file also finds baseline entries for deleted files
+	complete := scanAll && len(args) == 0
+
+	// Load baseline of known findings
+	var base *baseline.Baseline
+	if baselinePath != "" {
+		base, err = baseline.Load(baselinePath)
+		if err != nil {
+			return configError(err)
+		}
+	}
+	if len(filesToScan) == 0 && (base == nil || !complete) {
+		return nil
+	}
+
+	// Scan files
+	allResults, failed := scanFiles(scnr, filesToScan)
+	suppressed := scnr.Suppressed()
+
+	// Only fail on findings that are not in the baseline
+	var stale []baseline.Entry
+	if base != nil {
+		var known []scanner.Result
+		allResults, known, stale = base.Filter(allResults, root, filesToScan, complete)
+		suppressed = append(suppressed, known...)
+	}
+
+	// Honeytokens, some PII and findings below fail_on are reported separately and do
+	// not fail the scan
+	var reported []scanner.Result
+	allResults = filterConfidence(allResults)
+	allResults, reported = splitFindings(allResults, cfg)
+	sortResults(allResults)
+	sortResults(reported)
 
 	// Output results
 	if jsonOutput != "" {
-		if err := outputJSON(allResults, jsonOutput); err != nil {
-			return fmt.Errorf("failed to write JSON output: %w", err)
+		if err := outputJSON(allResults, reported, suppressed, jsonOutput); err != nil {
+			return runtimeError(fmt.Errorf("failed to write JSON output: %w", err))
 		}
 		fmt.Printf("Results written to %s\n", jsonOutput)
 	} else {
-		outputHumanReadable(allResults, explain)
+		outputHumanReadable(allResults, len(reported), explain)
+		outputReported(reported)
+		outputSuppressed(suppressed, explain)
 	}
+	outputStaleBaseline(stale)
 
 	// Handle interactive mode
 	if interactive && len(allResults) > 0 {
 		shouldRescan, err := runInteractive(allResults, workDir)
 		if err != nil {
-			return fmt.Errorf("interactive mode error: %w", err)
+			return runtimeError(fmt.Errorf("interactive mode error: %w", err))
 		}
 
 		if shouldRescan {
 			// Reload ignore patterns after updating .leakyrepoignore
 			ignorePatterns, err = ignore.LoadIgnorePatterns(filepath.Join(workDir, ".leakyrepoignore"))
 			if err != nil {
-				return fmt.Errorf("failed to reload ignore patterns: %w", err)
+				return configError(fmt.Errorf("failed to reload ignore patterns: %w", err))
 			}
 
 			// Re-create scanner with updated ignore patterns
-			scnr, err = scanner.NewScanner(cfg, ignorePatterns)
+			scnr, err = newScanner(cfg, ignorePatterns, root)
 			if err != nil {
-				return fmt.Errorf("failed to create scanner: %w", err)
+				return err
 			}
 
 			// Re-scan files
-			var newResults []scanner.Result
-			for _, file := range filesToScan {
-				results, err := scnr.ScanFile(file)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: failed to scan %s: %v\n", file, err)
-					continue
-				}
-				newResults = append(newResults, results...)
+			newResults, _ := scanFiles(scnr, filesToScan)
+			if base != nil {
+				newResults, _, _ = base.Filter(newResults, root, filesToScan, complete)
 			}
+			newResults, _ = splitFindings(filterConfidence(newResults), cfg)
+			sortResults(newResults)
 
 			if len(newResults) > 0 {
 				fmt.Printf("\n⚠️  Still found %d potential secret(s) after ignoring:\n\n", len(newResults))
-				outputHumanReadable(newResults, explain)
+				outputHumanReadable(newResults, 0, explain)
 				fmt.Println("\nPlease review remaining findings or add more ignore patterns.")
-				os.Exit(1)
+				os.Exit(exitFindings)
 			} else {
 				fmt.Println("✓ No secrets found! All ignored patterns applied successfully.")
 				return nil
@@ -184,31 +282,89 @@ func runScan(cmd *cobra.Command, args []string) error {
 			fmt.Println("\n💡 Tip: Run with --interactive (-i) to ignore false positives interactively")
 			fmt.Println("   Or use: leakyrepo ignore <file>")
 		}
-		os.Exit(1)
+		os.Exit(exitFindings)
+	}
+
+	// An incomplete scan is not a clean one
+	if failed > 0 {
+		return runtimeError(fmt.Errorf("%d file(s) could not be scanned", failed))
 	}
 
 	return nil
 }
 
-func outputJSON(results []scanner.Result, outputPath string) error {
+func outputJSON(failing, reported, suppressed []scanner.Result, outputPath string) error {
 	// Convert to JSON format as specified
 	type JSONResult struct {
-		File    string `json:"file"`
-		Line    int    `json:"line"`
-		RuleID  string `json:"rule_id,omitempty"`
-		Severity string `json:"severity"`
-		Match   string `json:"match"`
+		File           string                    `json:"file"`
+		Line           int                       `json:"line"`
+		StartColumn    int                       `json:"start_column,omitempty"`
+		EndColumn      int                       `json:"end_column,omitempty"`
+		Offset         int                       `json:"offset"`
+		Length         int                       `json:"length,omitempty"`
+		KeyPath        string                    `json:"key_path,omitempty"`
+		RuleID         string                    `json:"rule_id,omitempty"`
+		Severity       string                    `json:"severity"`
+		Match          string                    `json:"match"`
+		Fingerprint    string                    `json:"fingerprint"`
+		Confidence     float64                   `json:"confidence"`
+		Classification string                    `json:"classification,omitempty"`
+		Metadata       map[string]string         `json:"metadata,omitempty"`
+		Related        []scanner.RelatedLocation `json:"related,omitempty"`
+		Blocking       bool                      `json:"blocking"`
+		Suppressed     bool                      `json:"suppressed,omitempty"`
+		Suppression    string                    `json:"suppression,omitempty"`
+		Reason         string                    `json:"reason,omitempty"`
 	}
 
-	jsonResults := make([]JSONResult, len(results))
+	// Blocking findings fail the scan; the others are below fail_on, or honeytokens
+	// and PII that are only reported
+	blocking := len(failing)
+	results := append(append([]scanner.Result{}, failing...), reported...)
+
+	jsonResults := make([]JSONResult, 0, len(results)+len(suppressed))
 	for i, r := range results {
-		jsonResults[i] = JSONResult{
-			File:     r.File,
-			Line:     r.Line,
-			RuleID:   r.RuleID,
-			Severity: r.Severity,
-			Match:    r.Match,
-		}
+		jsonResults = append(jsonResults, JSONResult{
+			File:           r.File,
+			Line:           r.Line,
+			StartColumn:    r.StartColumn,
+			EndColumn:      r.EndColumn,
+			Offset:         r.Offset,
+			Length:         r.Length,
+			KeyPath:        r.KeyPath,
+			RuleID:         r.RuleID,
+			Severity:       r.Severity,
+			Match:          r.Match,
+			Fingerprint:    r.Fingerprint,
+			Confidence:     r.Confidence,
+			Classification: r.Classification,
+			Metadata:       r.Metadata,
+			Related:        r.Related,
+			Blocking:       i < blocking,
+		})
+	}
+	// Suppressed findings are listed too so reviewers can audit them
+	for _, r := range suppressed {
+		jsonResults = append(jsonResults, JSONResult{
+			File:           r.File,
+			Line:           r.Line,
+			StartColumn:    r.StartColumn,
+			EndColumn:      r.EndColumn,
+			Offset:         r.Offset,
+			Length:         r.Length,
+			KeyPath:        r.KeyPath,
+			RuleID:         r.RuleID,
+			Severity:       r.Severity,
+			Match:          r.Match,
+			Fingerprint:    r.Fingerprint,
+			Confidence:     r.Confidence,
+			Classification: r.Classification,
+			Metadata:       r.Metadata,
+			Related:        r.Related,
+			Suppressed:     true,
+			Suppression:    r.Suppression,
+			Reason:         r.SuppressionReason,
+		})
 	}
 
 	data, err := json.MarshalIndent(jsonResults, "", "  ")
@@ -219,9 +375,15 @@ func outputJSON(results []scanner.Result, outputPath string) error {
 	return os.WriteFile(outputPath, data, 0644)
 }
 
-func outputHumanReadable(results []scanner.Result, explain bool) {
+// outputHumanReadable prints the findings that fail the scan; reported is the number
+// of findings listed separately as not failing
+func outputHumanReadable(results []scanner.Result, reported int, explain bool) {
 	if len(results) == 0 {
-		fmt.Println("✓ No secrets found!")
+		if reported > 0 {
+			fmt.Println("✓ No findings that fail the scan")
+		} else {
+			fmt.Println("✓ No secrets found!")
+		}
 		return
 	}
 
@@ -235,6 +397,7 @@ func outputHumanReadable(results []scanner.Result, explain bool) {
 	}
 	resetColor := "\033[0m"
 	lockEmoji := "🔒"
+	lineCache := make(map[string][]string)
 
 	for _, result := range results {
 		color := severityColors[result.Severity]
@@ -242,18 +405,32 @@ func outputHumanReadable(results []scanner.Result, explain bool) {
 			color = "\033[33m" // Default to yellow
 		}
 
-		// Format: 🔒 [High] AWS Access Key found in config.env: AKIA...
-		fmt.Printf("%s %s[%s] %s found in %s:%d\n",
+		// Format: 🔒 [High] AWS Access Key found in config.env:3 (confidence 0.90)
+		fmt.Printf("%s %s[%s] %s found in %s:%d (confidence %.2f)\n",
 			lockEmoji,
 			color,
 			result.Severity,
 			result.Description,
 			result.File,
 			result.Line,
+			result.Confidence,
 		)
 
 		// Show masked match
 		fmt.Printf("   Match: %s%s%s\n", color, result.Match, resetColor)
+		if text, underline := underlineSpan(sourceLine(lineCache, result), result); underline != "" {
+			fmt.Printf("   %d:%d  %s\n", result.Line, result.StartColumn, text)
+			fmt.Printf("   %s  %s%s%s\n", strings.Repeat(" ", len(fmt.Sprintf("%d:%d", result.Line, result.StartColumn))), color, underline, resetColor)
+		}
+		if result.KeyPath != "" {
+			fmt.Printf("   Key: %s\n", result.KeyPath)
+		}
+		if len(result.Metadata) > 0 {
+			fmt.Printf("   Details: %s\n", formatMetadata(result.Metadata))
+		}
+		for _, related := range result.Related {
+			fmt.Printf("   Paired with: %d:%d %s\n", related.Line, related.StartColumn, related.Match)
+		}
 
 		// Show explanation if requested
 		if explain {
@@ -262,18 +439,209 @@ func outputHumanReadable(results []scanner.Result, explain bool) {
 					result.RuleID,
 					getRulePattern(result.RuleID),
 				)
+				if len(result.Related) > 0 {
+					fmt.Println("   Severity raised: the rule's required patterns were found nearby")
+				}
+			} else if result.DetectionType == "detector" {
+				fmt.Printf("   Reason: Matched built-in detector '%s'\n", result.RuleID)
+			} else if result.DetectionType == "denylist" {
+				fmt.Println("   Reason: Secret matches a hash in the denylist of known-leaked credentials")
 			} else if result.DetectionType == "entropy" {
 				threshold := getEntropyThreshold()
 				fmt.Printf("   Reason: High entropy detected (entropy above threshold: %.2f)\n",
 					threshold,
 				)
 			}
+			fmt.Printf("   Fingerprint: %s\n", result.Fingerprint)
 		}
 
 		fmt.Println()
 	}
 }
 
+// formatMetadata formats result metadata as sorted key=value pairs
+func formatMetadata(metadata map[string]string) string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, metadata[key]))
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// spanContext is how many characters of the line are shown on each side of a finding
+const spanContext = 30
+
+// sourceLine returns the line of the file where a result was found, reading each file once
+func sourceLine(cache map[string][]string, result scanner.Result) string {
+	lines, ok := cache[result.File]
+	if !ok {
+		if content, err := os.ReadFile(result.File); err == nil {
+			lines = strings.Split(string(content), "\n")
+		}
+		cache[result.File] = lines
+	}
+	if result.Line < 1 || result.Line > len(lines) {
+		return ""
+	}
+	return lines[result.Line-1]
+}
+
+// underlineSpan returns the line with the secret masked, trimmed around the finding on
+// long lines, and a row of carets under the secret. Both are empty if the span is unknown.
+func underlineSpan(line string, result scanner.Result) (string, string) {
+	runes := []rune(strings.ReplaceAll(line, "\t", " "))
+	start, end := result.StartColumn-1, result.EndColumn-1
+	if result.StartColumn == 0 || start >= end || end > len(runes) {
+		return "", ""
+	}
+
+	before := string(runes[max(0, start-spanContext):start])
+	if start > spanContext {
+		before = "..." + before
+	}
+	after := string(runes[end:min(len(runes), end+spanContext)])
+	if len(runes)-end > spanContext {
+		after += "..."
+	}
+	masked := scanner.MaskMatch(string(runes[start:end]), 4)
+
+	text := strings.TrimRight(before+masked+after, "\r")
+	underline := strings.Repeat(" ", len([]rune(before))) + strings.Repeat("^", len([]rune(masked)))
+	return text, underline
+}
+
+// filterConfidence drops findings below --min-confidence
+func filterConfidence(results []scanner.Result) []scanner.Result {
+	if minConfidence == 0 {
+		return results
+	}
+	var kept []scanner.Result
+	for _, result := range results {
+		if result.Confidence >= minConfidence {
+			kept = append(kept, result)
+		}
+	}
+	if hidden := len(results) - len(kept); hidden > 0 {
+		fmt.Printf("%d finding(s) below --min-confidence %.2f hidden\n", hidden, minConfidence)
+	}
+	return kept
+}
+
+// sortResults orders findings by confidence, most likely first, when --sort confidence is set
+func sortResults(results []scanner.Result) {
+	if sortBy != "confidence" {
+		return
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Confidence > results[j].Confidence
+	})
+}
+
+// splitFindings separates the findings that fail the scan from those that are only
+// reported: findings below fail_on, honeytokens (unless fail_on_canary is set) and
+// PII types not in pii.fail_on
+func splitFindings(results []scanner.Result, cfg *config.Config) (failing, reported []scanner.Result) {
+	for _, result := range results {
+		if failsScan(result, cfg) {
+			failing = append(failing, result)
+		} else {
+			reported = append(reported, result)
+		}
+	}
+	return failing, reported
+}
+
+// failsScan reports whether a finding should fail the scan
+func failsScan(result scanner.Result, cfg *config.Config) bool {
+	if cfg.FailOn != "" && !scanner.MeetsSeverity(result.Severity, cfg.FailOn) {
+		return false
+	}
+	switch result.Classification {
+	case scanner.ClassificationCanary:
+		return cfg.FailOnCanary
+	case scanner.ClassificationPII:
+		if len(cfg.PII.FailOn) == 0 {
+			return true
+		}
+		for _, piiType := range cfg.PII.FailOn {
+			if piiType == result.Metadata["pii_type"] {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// outputReported lists findings that are reported without failing the scan
+func outputReported(reported []scanner.Result) {
+	if len(reported) == 0 {
+		return
+	}
+
+	fmt.Printf("ℹ️  %d finding(s) reported but not failing:\n", len(reported))
+	for _, result := range reported {
+		fmt.Printf("   %s:%d %s (%s)\n", result.File, result.Line, result.Description, result.Match)
+	}
+	fmt.Println()
+}
+
+// outputSuppressed summarizes suppressed findings, listing each one in explain mode
+func outputSuppressed(suppressed []scanner.Result, explain bool) {
+	if len(suppressed) == 0 {
+		return
+	}
+
+	counts := make(map[string]int)
+	var kinds []string
+	for _, result := range suppressed {
+		if counts[result.Suppression] == 0 {
+			kinds = append(kinds, result.Suppression)
+		}
+		counts[result.Suppression]++
+	}
+	var parts []string
+	for _, kind := range kinds {
+		parts = append(parts, fmt.Sprintf("%s: %d", kind, counts[kind]))
+	}
+	fmt.Printf("ℹ️  %d finding(s) suppressed (%s)\n", len(suppressed), strings.Join(parts, ", "))
+
+	if !explain {
+		return
+	}
+	for _, result := range suppressed {
+		reason := result.SuppressionReason
+		if reason == "" {
+			reason = result.Suppression
+		}
+		fmt.Printf("   %s:%d [%s] %s (%s)\n", result.File, result.Line, result.Severity, result.Description, reason)
+	}
+	fmt.Println()
+}
+
+// outputStaleBaseline lists baseline entries that no longer match any finding
+func outputStaleBaseline(stale []baseline.Entry) {
+	if len(stale) == 0 {
+		return
+	}
+
+	fmt.Printf("ℹ️  %d baseline entr(ies) no longer match and can be removed:\n", len(stale))
+	for _, entry := range stale {
+		rule := entry.RuleID
+		if rule == "" {
+			rule = "entropy"
+		}
+		fmt.Printf("   %s (%s, %s) fingerprint %s\n", entry.File, rule, entry.Match, entry.Fingerprint)
+	}
+	fmt.Println("   Run 'leakyrepo baseline create' to refresh the baseline.")
+}
+
 // Helper functions for explain output
 func getRulePattern(ruleID string) string {
 	// This would ideally load from config, but for simplicity we'll just return a placeholder
@@ -306,4 +674,3 @@ func getEntropyThreshold() float64 {
 	}
 	return cfg.EntropyThreshold
 }
-
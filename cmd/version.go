@@ -23,6 +23,7 @@ var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print version information",
 	Long:  "Print the version number and build information for LeakyRepo",
+	Args:  usageArgs(cobra.NoArgs),
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Printf("leakyrepo version %s\n", version)
 		fmt.Printf("commit: %s\n", commit)
@@ -33,4 +34,3 @@ var versionCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(versionCmd)
 }
-
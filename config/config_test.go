@@ -73,3 +73,76 @@ func TestSaveAndLoadConfig(t *testing.T) {
 	}
 }
 
+func TestAddAllowlistHashes(t *testing.T) {
+	hash := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "existing hashes",
+			content:  "# my comments\nentropy_threshold: 4.5\n\nallowlist:\n  hashes:\n    - \"aaaa\" # revoked\n\n# trailing comment\n",
+			expected: "# my comments\nentropy_threshold: 4.5\n\nallowlist:\n  hashes:\n    - \"aaaa\" # revoked\n    - " + hash + "\n\n# trailing comment\n",
+		},
+		{
+			name:     "empty flow list",
+			content:  "allowlist:\n  hashes: [] # none yet\n",
+			expected: "allowlist:\n  hashes: [" + hash + "] # none yet\n",
+		},
+		{
+			name:     "null hashes",
+			content:  "allowlist:\n    hashes:\nrules: []\n",
+			expected: "allowlist:\n    hashes:\n      - " + hash + "\nrules: []\n",
+		},
+		{
+			name:     "tilde hashes",
+			content:  "allowlist:\n  hashes: ~\n",
+			expected: "allowlist:\n  hashes:\n    - " + hash + "\n",
+		},
+		{
+			name:     "null hashes with comment",
+			content:  "allowlist:\n  hashes: null # none\n",
+			expected: "allowlist:\n  hashes: # none\n    - " + hash + "\n",
+		},
+		{
+			name:     "null allowlist",
+			content:  "allowlist: ~\nrules: []\n",
+			expected: "allowlist:\n  hashes:\n    - " + hash + "\nrules: []\n",
+		},
+		{
+			name:     "allowlist without hashes",
+			content:  "allowlist:\n  regexes:\n    - 'example'\n",
+			expected: "allowlist:\n  hashes:\n    - " + hash + "\n  regexes:\n    - 'example'\n",
+		},
+		{
+			name:     "no allowlist",
+			content:  "# my comments\nrules: []\n",
+			expected: "# my comments\nrules: []\n\nallowlist:\n  hashes:\n    - " + hash + "\n",
+		},
+		{
+			name:     "empty file",
+			content:  "",
+			expected: "allowlist:\n  hashes:\n    - " + hash + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".leakyrepo.yml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to create config file: %v", err)
+			}
+			if err := AddAllowlistHashes(configPath, []string{hash}); err != nil {
+				t.Fatalf("AddAllowlistHashes() error = %v", err)
+			}
+			data, err := os.ReadFile(configPath)
+			if err != nil {
+				t.Fatalf("Failed to read config file: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("AddAllowlistHashes() wrote:\n%s\nexpected:\n%s", data, tt.expected)
+			}
+		})
+	}
+}
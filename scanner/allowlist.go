@@ -0,0 +1,197 @@
+package scanner
+
+import (
+	"encoding/hex"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+// ruleAllowlist is the compiled form of a config.RuleAllowlist
+type ruleAllowlist struct {
+	paths     []string
+	regexes   []*regexp.Regexp
+	stopwords []string
+}
+
+// compileRuleAllowlist compiles the allowlist of a single rule
+func compileRuleAllowlist(rule config.Rule) (*ruleAllowlist, error) {
+	if rule.Allowlist == nil {
+		return nil, nil
+	}
+
+	allowlist := &ruleAllowlist{
+		paths: rule.Allowlist.Paths,
+	}
+	for _, expr := range rule.Allowlist.Regexes {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowlist regex %q for rule %s: %w", expr, rule.ID, err)
+		}
+		allowlist.regexes = append(allowlist.regexes, re)
+	}
+	for _, word := range rule.Allowlist.Stopwords {
+		allowlist.stopwords = append(allowlist.stopwords, strings.ToLower(word))
+	}
+
+	return allowlist, nil
+}
+
+// allowsPath reports whether the rule is disabled for the given relative path
+func (a *ruleAllowlist) allowsPath(relPath string) bool {
+	if a == nil {
+		return false
+	}
+	for _, pattern := range a.paths {
+		if matchPathPattern(pattern, relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsSecret reports whether a secret value is allowlisted for the rule
+func (a *ruleAllowlist) allowsSecret(secret string) bool {
+	if a == nil {
+		return false
+	}
+	for _, re := range a.regexes {
+		if re.MatchString(secret) {
+			return true
+		}
+	}
+	lower := strings.ToLower(secret)
+	for _, word := range a.stopwords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAllowlisted checks a match and its secret value against the global allowlist.
+// Allowlist strings are matched as substrings of the whole match, while allowlist
+// regexes and hashes are applied to the secret value only.
+func (s *Scanner) isAllowlisted(match, secret, secretHash string) bool {
+	if s.allowHashes[secretHash] {
+		return true
+	}
+	for _, allowed := range s.config.Allowlist.Strings {
+		if strings.Contains(match, allowed) {
+			return true
+		}
+	}
+	for _, re := range s.allowRegexes {
+		if re.MatchString(secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSHA256Hex reports whether s is a hex-encoded SHA-256 digest
+func isSHA256Hex(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// matchPathPattern matches a slash-separated relative path against a glob pattern.
+// In addition to filepath.Match syntax, "**" matches any number of directories, and
+// patterns without a slash are also matched against the base name.
+func matchPathPattern(pattern, relPath string) bool {
+	pattern = strings.TrimPrefix(strings.ReplaceAll(pattern, "\\", "/"), "./")
+	relPath = strings.ReplaceAll(relPath, "\\", "/")
+
+	// Directory patterns like "docs/" match everything below the directory
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+
+	if !strings.Contains(pattern, "/") {
+		if matched, _ := path.Match(pattern, path.Base(relPath)); matched {
+			return true
+		}
+	}
+
+	if !strings.Contains(pattern, "**") {
+		matched, _ := path.Match(pattern, relPath)
+		return matched
+	}
+
+	return globstarRegexp(pattern).MatchString(relPath)
+}
+
+// globstarRegexp converts a glob pattern containing "**" into a regular expression
+func globstarRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '*' && i+1 < len(pattern) && pattern[i+1] == '*':
+			i++
+			if i+1 < len(pattern) && pattern[i+1] == '/' {
+				// "**/" matches zero or more leading directories
+				i++
+				b.WriteString("(?:.*/)?")
+			} else {
+				b.WriteString(".*")
+			}
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// isKeyPathAllowlisted reports whether a structured key path matches allowlist.key_paths
+func (s *Scanner) isKeyPathAllowlisted(keyPath string) bool {
+	if keyPath == "" {
+		return false
+	}
+	keyPath = trimKeyPathRoot(keyPath)
+	for _, re := range s.allowKeyPaths {
+		if re.MatchString(keyPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyPathRegexp converts a key path pattern like "spring.*.password" into a regular
+// expression. "*" matches a single key and "**" matches any number of keys.
+func keyPathRegexp(pattern string) *regexp.Regexp {
+	pattern = trimKeyPathRoot(pattern)
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '*' && i+1 < len(pattern) && pattern[i+1] == '*':
+			i++
+			b.WriteString(".*")
+		case c == '*':
+			b.WriteString("[^.]*")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// trimKeyPathRoot removes the "$." root marker used for JSON key paths
+func trimKeyPathRoot(keyPath string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(keyPath, "$"), ".")
+}
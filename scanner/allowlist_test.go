@@ -0,0 +1,252 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+func TestMatchPathPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		relPath  string
+		expected bool
+	}{
+		{pattern: "docs/**", relPath: "docs/guide/setup.md", expected: true},
+		{pattern: "docs/**", relPath: "src/docs.go", expected: false},
+		{pattern: "docs/", relPath: "docs/README.md", expected: true},
+		{pattern: "**/testdata/**", relPath: "pkg/api/testdata/token.json", expected: true},
+		{pattern: "**/testdata/**", relPath: "testdata/token.json", expected: true},
+		{pattern: "*.md", relPath: "docs/README.md", expected: true},
+		{pattern: "src/*.go", relPath: "src/main.go", expected: true},
+		{pattern: "src/*.go", relPath: "src/pkg/main.go", expected: false},
+		{pattern: "src/**/*.go", relPath: "src/pkg/main.go", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.relPath, func(t *testing.T) {
+			result := matchPathPattern(tt.pattern, tt.relPath)
+			if result != tt.expected {
+				t.Errorf("matchPathPattern(%q, %q) = %v, expected %v",
+					tt.pattern, tt.relPath, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScanner_RuleAllowlist(t *testing.T) {
+	tmpDir := t.TempDir()
+	docsDir := filepath.Join(tmpDir, "docs")
+	if err := os.MkdirAll(docsDir, 0755); err != nil {
+		t.Fatalf("Failed to create docs dir: %v", err)
+	}
+
+	testContent := `api_key = "k8Fj2LmQ9zXv7Rt3Wp5Yb1Nc"
+aws = "AKIAZ7Q4M2XW9LRT5BNC"
+api_key = "example_k8Fj2LmQ9zXv7Rt3Wp"
+`
+	docsFile := filepath.Join(docsDir, "setup.py")
+	srcFile := filepath.Join(tmpDir, "app.py")
+	for _, file := range []string{docsFile, srcFile} {
+		if err := os.WriteFile(file, []byte(testContent), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	cfg := &config.Config{
+		EntropyThreshold: 8.0,
+		Rules: []config.Rule{
+			{
+				ID:       "aws_access_key",
+				Severity: "high",
+				Pattern:  `AKIA[0-9A-Z]{16}`,
+			},
+			{
+				ID:       "generic_api_key",
+				Severity: "medium",
+				Pattern:  `(?i)(api[_-]?key)\s*=\s*"([a-zA-Z0-9_\-]{20,})"`,
+				Allowlist: &config.RuleAllowlist{
+					Paths:     []string{"docs/**"},
+					Stopwords: []string{"EXAMPLE"},
+				},
+			},
+		},
+	}
+
+	scnr, err := NewScannerWithOptions(cfg, []string{}, Options{Root: tmpDir})
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	tests := []struct {
+		file     string
+		expected map[string]int
+	}{
+		{file: docsFile, expected: map[string]int{"aws_access_key": 1}},
+		{file: srcFile, expected: map[string]int{"aws_access_key": 1, "generic_api_key": 1}},
+	}
+
+	for _, tt := range tests {
+		results, err := scnr.ScanFile(tt.file)
+		if err != nil {
+			t.Fatalf("Failed to scan file: %v", err)
+		}
+
+		counts := make(map[string]int)
+		for _, result := range results {
+			counts[result.RuleID]++
+		}
+		for ruleID, expected := range tt.expected {
+			if counts[ruleID] != expected {
+				t.Errorf("%s: expected %d %s result(s), got %d", tt.file, expected, ruleID, counts[ruleID])
+			}
+		}
+		if len(results) != len(tt.expected) {
+			t.Errorf("%s: expected %d result(s), got %d", tt.file, len(tt.expected), len(results))
+		}
+	}
+}
+
+func TestNewScanner_InvalidRuleAllowlistRegex(t *testing.T) {
+	cfg := &config.Config{
+		Rules: []config.Rule{
+			{
+				ID:        "generic_api_key",
+				Pattern:   `api_key=\w+`,
+				Allowlist: &config.RuleAllowlist{Regexes: []string{"("}},
+			},
+		},
+	}
+
+	if _, err := NewScanner(cfg, []string{}); err == nil {
+		t.Error("Expected error for invalid allowlist regex, got nil")
+	}
+}
+
+func TestScanner_AllowlistRegexes(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "keys.env")
+	testContent := `AWS_KEY=AKIAZ7Q4M2XW9LRTEXAMPLE
+AWS_KEY=AKIAZ7Q4M2XW9LRT5BNC
+AWS_KEY=AKIAQ3J8M2XW9LRT5BNC # fixture
+`
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cfg := &config.Config{
+		EntropyThreshold: 8.0,
+		Rules: []config.Rule{
+			{
+				ID:       "aws_access_key",
+				Severity: "high",
+				Pattern:  `AKIA[0-9A-Z]{16,20}`,
+			},
+		},
+		Allowlist: config.Allowlist{
+			Regexes:     []string{`^AKIA.*EXAMPLE$`},
+			LineRegexes: []string{`#\s*fixture`},
+		},
+	}
+
+	scnr, err := NewScanner(cfg, []string{})
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	results, err := scnr.ScanFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to scan file: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+	if results[0].Line != 2 {
+		t.Errorf("Expected match on line 2, got line %d", results[0].Line)
+	}
+}
+
+func TestNewScanner_InvalidAllowlistRegexes(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowlist config.Allowlist
+		entry     string
+	}{
+		{
+			name:      "invalid secret regex",
+			allowlist: config.Allowlist{Regexes: []string{"sk_[a-z"}},
+			entry:     "sk_[a-z",
+		},
+		{
+			name:      "invalid line regex",
+			allowlist: config.Allowlist{LineRegexes: []string{"(fixture"}},
+			entry:     "(fixture",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewScanner(&config.Config{Allowlist: tt.allowlist}, []string{})
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.entry) {
+				t.Errorf("Expected error to name entry %q, got %v", tt.entry, err)
+			}
+		})
+	}
+}
+
+func TestScanner_AllowlistHashes(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "keys.env")
+	testContent := `AWS_KEY=AKIAZ7Q4M2XW9LRT5BNC
+AWS_KEY=AKIAQ3J8M2XW9LRT5BNC
+`
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cfg := &config.Config{
+		EntropyThreshold: 8.0,
+		Rules: []config.Rule{
+			{
+				ID:       "aws_access_key",
+				Severity: "high",
+				Pattern:  `AKIA[0-9A-Z]{16}`,
+			},
+		},
+		Allowlist: config.Allowlist{
+			Hashes: []string{HashSecret("AKIAZ7Q4M2XW9LRT5BNC")},
+		},
+	}
+
+	scnr, err := NewScanner(cfg, []string{})
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	results, err := scnr.ScanFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to scan file: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+	if results[0].Line != 2 {
+		t.Errorf("Expected match on line 2, got line %d", results[0].Line)
+	}
+	if results[0].SecretHash != HashSecret("AKIAQ3J8M2XW9LRT5BNC") {
+		t.Errorf("Expected SecretHash of the matched key, got %q", results[0].SecretHash)
+	}
+
+	cfg.Allowlist.Hashes = []string{"not-a-hash"}
+	if _, err := NewScanner(cfg, []string{}); err == nil {
+		t.Error("Expected error for invalid allowlist hash, got nil")
+	}
+}
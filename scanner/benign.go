@@ -0,0 +1,123 @@
+package scanner
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// BenignTokenTypes are the known-benign token recognizers, in the order they are tried
+var BenignTokenTypes = []string{"uuid", "git_sha", "sri_hash", "checksum", "data_uri", "pgp_public_key"}
+
+var (
+	uuidPattern   = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	gitSHAPattern = regexp.MustCompile(`\b[0-9a-f]{40}\b`)
+	// sriPattern matches Subresource Integrity hashes (integrity="sha384-...")
+	sriPattern = regexp.MustCompile(`\bsha(?:256|384|512)-[A-Za-z0-9+/]{43,86}={0,2}`)
+	// checksumPattern matches digests with an algorithm prefix (go.sum "h1:", Docker
+	// "sha256:") and hex digests under checksum keys (Cargo.lock "checksum = ...")
+	checksumPattern = regexp.MustCompile(`(?i)\b(?:h1|md5|sha1|sha224|sha256|sha384|sha512):[A-Za-z0-9+/]{22,}={0,2}|\b(?:checksum|digest|integrity|hash|sha1|sha256|sha512|md5)\b["']?\s*[:=]\s*["']?[0-9a-f]{32,128}\b`)
+	dataURIPattern  = regexp.MustCompile(`\bdata:[\w.+-]+/[\w.+-]+(?:;[\w.+-]+=[\w.+-]+)*;base64,[A-Za-z0-9+/]+={0,2}`)
+)
+
+// benignRecognizer identifies high-entropy tokens that are clearly not secrets
+type benignRecognizer struct {
+	description string
+	// match reports whether line[start:end] is a benign token
+	match func(ctx *fileContext, line string, start, end int) bool
+}
+
+var benignRecognizers = map[string]benignRecognizer{
+	"uuid":     {description: "UUID", match: withinPattern(uuidPattern)},
+	"git_sha":  {description: "git commit SHA", match: withinPattern(gitSHAPattern)},
+	"sri_hash": {description: "Subresource Integrity hash", match: withinPattern(sriPattern)},
+	"checksum": {description: "checksum", match: withinPattern(checksumPattern)},
+	"data_uri": {description: "base64 data URI", match: withinPattern(dataURIPattern)},
+	"pgp_public_key": {description: "PGP public key block", match: func(ctx *fileContext, line string, start, end int) bool {
+		return ctx.pgpPublicKey
+	}},
+}
+
+// compileBenignRecognizers returns the recognizers that are not disabled in config
+func compileBenignRecognizers(disabled []string) ([]benignRecognizer, error) {
+	skip := make(map[string]bool, len(disabled))
+	for _, name := range disabled {
+		if _, ok := benignRecognizers[name]; !ok {
+			return nil, fmt.Errorf("unknown disable_benign_tokens entry %q (expected one of %s)", name, strings.Join(BenignTokenTypes, ", "))
+		}
+		skip[name] = true
+	}
+	var recognizers []benignRecognizer
+	for _, name := range BenignTokenTypes {
+		if !skip[name] {
+			recognizers = append(recognizers, benignRecognizers[name])
+		}
+	}
+	return recognizers, nil
+}
+
+// withinPattern returns a matcher for tokens covered by matches of pattern. Tokens
+// from plain text can carry surrounding text (src="app.js" integrity="sha384-..."),
+// so a token is covered if what is left outside the matches is too short to be a secret.
+func withinPattern(pattern *regexp.Regexp) func(ctx *fileContext, line string, start, end int) bool {
+	return func(ctx *fileContext, line string, start, end int) bool {
+		covered := false
+		pos := start
+		for _, loc := range pattern.FindAllStringIndex(line, -1) {
+			if loc[1] <= start || loc[0] >= end {
+				continue
+			}
+			covered = true
+			if longestTokenRun(line[pos:max(pos, loc[0])]) >= minSecretLength {
+				return false
+			}
+			pos = max(pos, loc[1])
+		}
+		return covered && longestTokenRun(line[min(pos, end):end]) < minSecretLength
+	}
+}
+
+// minSecretLength is the shortest token checked for high entropy
+const minSecretLength = 16
+
+// longestTokenRun returns the length of the longest run of token characters in s
+func longestTokenRun(s string) int {
+	longest, run := 0, 0
+	for i := 0; i < len(s); i++ {
+		if isTokenChar(s[i]) {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
+
+// trackBlocks updates the multi-line block state of the file before a line is scanned
+func (ctx *fileContext) trackBlocks(line string) {
+	switch {
+	case strings.Contains(line, "-----BEGIN PGP PUBLIC KEY BLOCK-----"):
+		ctx.pgpPublicKey = true
+	case strings.Contains(line, "-----END PGP PUBLIC KEY BLOCK-----"):
+		ctx.pgpPublicKey = false
+	}
+}
+
+// suppressBenign records the result as suppressed if the token at line[start:] is
+// recognized as benign (a UUID, git SHA, checksum, ...), and reports whether it did
+func (s *Scanner) suppressBenign(result Result, ctx *fileContext, line, token string, start int) bool {
+	if start < 0 {
+		// The token was not found on the line, so judge it on its own
+		line, start = token, 0
+	}
+	for _, recognizer := range s.benign {
+		if recognizer.match(ctx, line, start, start+len(token)) {
+			result.Suppression = "benign"
+			result.SuppressionReason = "Looks like a " + recognizer.description
+			s.suppressed = append(s.suppressed, result)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+func TestScanner_BenignTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		benign  string
+		content string
+	}{
+		{"uuid", "uuid", "request_id: 3f2c8a91-7b4e-4d2a-9c6f-1e8b5d0a7f3c\n"},
+		{"git sha", "git_sha", "commit 9fceb02d0ae598e95dc970b74767f19372d61af8\n"},
+		{"sri hash", "sri_hash", `<script src="app.js" integrity="sha384-oqVuAfXRKap7fdgcCY5uykM6+R9GqQ8K/uxy9rx7HNQlGYl1kPzQho1wx4JwY8wC"></script>` + "\n"},
+		{"go.sum checksum", "checksum", "golang.org/x/text v0.3.0 h1:g61tztE5qeGQ89tm6NTjjM9VPIm088od1l6aSorWRWg=\n"},
+		{"cargo checksum", "checksum", `checksum = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"` + "\n"},
+		{"data uri", "data_uri", "background: url(data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNkYPhfDwAChwGA60e6kgAAAABJRU5ErkJggg==)\n"},
+		{"pgp public key", "pgp_public_key", "-----BEGIN PGP PUBLIC KEY BLOCK-----\n\nmQINBFsB9x8BEADJr5Zq3pFk7Wm2LxQ9vTn4Yd8Hs1Kc6Gb0Ra3Ue5Ij7Oh2Pl\n-----END PGP PUBLIC KEY BLOCK-----\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFile := filepath.Join(t.TempDir(), "fixture.txt")
+			if err := os.WriteFile(testFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+
+			cfg := &config.Config{EntropyThreshold: 3.0}
+			scnr, err := NewScanner(cfg, []string{})
+			if err != nil {
+				t.Fatalf("Failed to create scanner: %v", err)
+			}
+			results, err := scnr.ScanFile(testFile)
+			if err != nil {
+				t.Fatalf("Failed to scan file: %v", err)
+			}
+			if len(results) != 0 {
+				t.Errorf("ScanFile(%q) = %+v, expected no findings", tt.content, results)
+			}
+			if suppressed := scnr.Suppressed(); len(suppressed) == 0 || suppressed[0].Suppression != "benign" {
+				t.Errorf("ScanFile(%q) suppressed = %+v, expected a benign suppression", tt.content, suppressed)
+			}
+
+			// Disabling the recognizer reports the token again
+			cfg.DisableBenignTokens = []string{tt.benign}
+			scnr, err = NewScanner(cfg, []string{})
+			if err != nil {
+				t.Fatalf("Failed to create scanner: %v", err)
+			}
+			results, err = scnr.ScanFile(testFile)
+			if err != nil {
+				t.Fatalf("Failed to scan file: %v", err)
+			}
+			if len(results) == 0 {
+				t.Errorf("ScanFile(%q) with %s disabled found nothing, expected an entropy finding", tt.content, tt.benign)
+			}
+		})
+	}
+}
+
+func TestCompileBenignRecognizers(t *testing.T) {
+	recognizers, err := compileBenignRecognizers([]string{"uuid", "git_sha"})
+	if err != nil {
+		t.Fatalf("compileBenignRecognizers() error = %v", err)
+	}
+	if len(recognizers) != len(BenignTokenTypes)-2 {
+		t.Errorf("compileBenignRecognizers() returned %d recognizers, expected %d", len(recognizers), len(BenignTokenTypes)-2)
+	}
+	if _, err := compileBenignRecognizers([]string{"ulid"}); err == nil {
+		t.Error("compileBenignRecognizers([ulid]) expected an error for an unknown type")
+	}
+}
+
+func TestWithinPattern(t *testing.T) {
+	match := withinPattern(uuidPattern)
+	tests := []struct {
+		line     string
+		expected bool
+	}{
+		{"3f2c8a91-7b4e-4d2a-9c6f-1e8b5d0a7f3c", true},
+		{`id="3f2c8a91-7b4e-4d2a-9c6f-1e8b5d0a7f3c" type="uuid"`, true},
+		{"3f2c8a91-7b4e-4d2a-9c6f-1e8b5d0a7f3c key=k8Fj2LmQ9zXv7Rt3Wp5Yb1NcQ4x", false},
+		{"k8Fj2LmQ9zXv7Rt3Wp5Yb1NcQ4x", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			if got := match(&fileContext{}, tt.line, 0, len(tt.line)); got != tt.expected {
+				t.Errorf("withinPattern(uuid)(%q) = %v, expected %v", tt.line, got, tt.expected)
+			}
+		})
+	}
+}
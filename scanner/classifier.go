@@ -0,0 +1,190 @@
+package scanner
+
+import (
+	_ "embed"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+//go:embed wordlist.txt
+var wordlistData string
+
+// wordlist holds common English words and programming terms
+var wordlist = parseWordlist(wordlistData)
+
+// urlPrefixPattern matches tokens that start with a URL scheme or "www."
+var urlPrefixPattern = regexp.MustCompile(`(?i)^([a-z][a-z0-9+.\-]*://|www\.)`)
+
+// Thresholds for deciding that a token is made of words rather than random characters
+const (
+	// minWordCoverage is the fraction of letters that must belong to recognized words
+	minWordCoverage = 0.8
+	// maxDigitRatio is the largest fraction of digits a word-like token may contain
+	maxDigitRatio = 0.25
+	// pronounceable words have a vowel ratio within these bounds and no long consonant runs
+	minVowelRatio    = 0.3
+	maxVowelRatio    = 0.6
+	maxConsonantRun  = 3
+	minInferredWord  = 4
+	maxPathExtension = 5
+)
+
+// wordSuffixes are stripped to match inflected words against the wordlist
+var wordSuffixes = []string{"ing", "ed", "es", "er", "s"}
+
+// parseWordlist reads one lowercase word per line, skipping comments
+func parseWordlist(data string) map[string]bool {
+	words := make(map[string]bool)
+	for _, line := range strings.Split(data, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			words[line] = true
+		}
+	}
+	return words
+}
+
+// classifyToken reports what kind of structured text a token is: "url", "path",
+// "identifier" (camelCase, snake_case, kebab-case) or "words". It returns an empty
+// string for tokens that look random, which are the candidates for secrets.
+func classifyToken(s string) string {
+	switch {
+	case urlPrefixPattern.MatchString(s):
+		return "url"
+	case strings.ContainsAny(s, `/\`) && isPath(s):
+		return "path"
+	case isWordy(s):
+		if strings.ContainsAny(s, "_-.") || hasCaseChange(s) {
+			return "identifier"
+		}
+		return "words"
+	}
+	return ""
+}
+
+// isPath reports whether every segment of a slash-separated token is a word,
+// an identifier or a short name like "v1"
+func isPath(s string) bool {
+	segments := strings.FieldsFunc(s, func(r rune) bool { return r == '/' || r == '\\' })
+	if len(segments) < 2 {
+		return false
+	}
+	for _, segment := range segments {
+		if dot := strings.LastIndex(segment, "."); dot > 0 && len(segment)-dot-1 <= maxPathExtension {
+			segment = segment[:dot]
+		}
+		if segment == "." || segment == ".." || len(segment) <= 3 {
+			continue
+		}
+		if !isWordy(segment) {
+			return false
+		}
+	}
+	return true
+}
+
+// isWordy reports whether most of the letters of a token belong to recognized words
+func isWordy(s string) bool {
+	letters, digits, wordLetters := 0, 0, 0
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+	if float64(digits) > float64(len(s))*maxDigitRatio {
+		return false
+	}
+
+	for _, part := range splitIdentifier(s) {
+		letters += len(part)
+		if isWord(part) {
+			wordLetters += len(part)
+		}
+	}
+	return letters > 0 && float64(wordLetters) >= float64(letters)*minWordCoverage
+}
+
+// isWord reports whether a part of an identifier is a known or pronounceable word
+func isWord(part string) bool {
+	word := strings.ToLower(part)
+	if len(word) < 2 {
+		return false
+	}
+	if wordlist[word] {
+		return true
+	}
+	for _, suffix := range wordSuffixes {
+		stem := strings.TrimSuffix(word, suffix)
+		if stem == word || len(stem) < 2 {
+			continue
+		}
+		// Inflections may add an "e" (changed) or double a consonant (scanner)
+		if wordlist[stem] || wordlist[stem+"e"] || (stem[len(stem)-1] == stem[len(stem)-2] && wordlist[stem[:len(stem)-1]]) {
+			return true
+		}
+	}
+	return len(word) >= minInferredWord && isPronounceable(word)
+}
+
+// isPronounceable reports whether a lowercase word has the vowel pattern of natural
+// language: a balanced vowel ratio and no long runs of consonants
+func isPronounceable(word string) bool {
+	vowels, run := 0, 0
+	for _, r := range word {
+		if strings.ContainsRune("aeiouy", r) {
+			vowels++
+			run = 0
+			continue
+		}
+		run++
+		if run > maxConsonantRun {
+			return false
+		}
+	}
+	ratio := float64(vowels) / float64(len(word))
+	return ratio >= minVowelRatio && ratio <= maxVowelRatio
+}
+
+// splitIdentifier splits a token into its alphabetic words at separators, digits and
+// case changes: "parseHTTPResponse_v2" becomes [parse HTTP Response v]
+func splitIdentifier(s string) []string {
+	var parts []string
+	runes := []rune(s)
+	start := -1
+	flush := func(end int) {
+		if start >= 0 && end > start {
+			parts = append(parts, string(runes[start:end]))
+		}
+		start = -1
+	}
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) {
+			flush(i)
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		prev := runes[i-1]
+		switch {
+		case unicode.IsLower(prev) && unicode.IsUpper(r):
+			// camelCase boundary
+			flush(i)
+			start = i
+		case unicode.IsUpper(prev) && unicode.IsUpper(r) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			// Acronym followed by a word, as in HTTPResponse
+			flush(i)
+			start = i
+		}
+	}
+	flush(len(runes))
+
+	return parts
+}
+
+// hasCaseChange reports whether a token mixes lower and upper case letters
+func hasCaseChange(s string) bool {
+	return strings.ToLower(s) != s && strings.ToUpper(s) != s
+}
@@ -0,0 +1,73 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClassifyToken(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		// Identifiers that used to need hard-coded exceptions
+		{"playbackPositionChanged", "identifier"},
+		{"DropdownMenuTriggerItem", "identifier"},
+		{"SUBSCRIPTION_LAST_UPDATED", "identifier"},
+		{"user-profile-avatar-image", "identifier"},
+		{"parseHTTPResponseHeaders", "identifier"},
+		{"getAccountSettingsName", "identifier"},
+		{"configuration", "words"},
+		{"src/components/Button.tsx", "path"},
+		{"../../internal/scanner/entropy.go", "path"},
+		{"https://api.github.com/repos/org/repo", "url"},
+		{"www.leakyrepo.dev/docs", "url"},
+
+		// Random-looking values are left for entropy detection
+		{"k8Fj2LmQ9zXv7Rt3Wp5Yb1NcQ4", ""},
+		{"wJalrXUtnFEMI/K7MDENG/bPxRfiCYzK3mQ", ""},
+		{"3fa85f6457174562b3fc2c963f66afa6", ""},
+		{"ghp_R4nd0mT0k3nV4lu3xyzAbCdEf", ""},
+		{"xkcdqwrtzplmnbvq", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := classifyToken(tt.input)
+			if result != tt.expected {
+				t.Errorf("classifyToken(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSplitIdentifier(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"parseHTTPResponse_v2", []string{"parse", "HTTP", "Response", "v"}},
+		{"snake_case_name", []string{"snake", "case", "name"}},
+		{"a1b2", []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := splitIdentifier(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("splitIdentifier(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsHighEntropy_Classifier(t *testing.T) {
+	for _, input := range []string{"playbackPositionChanged", "DropdownMenuTriggerItem", "https://api.github.com/repos/org/repo"} {
+		if IsHighEntropy(input, 3.0) {
+			t.Errorf("IsHighEntropy(%q, 3.0) = true, expected structured text to be excluded", input)
+		}
+	}
+	if !IsHighEntropy("k8Fj2LmQ9zXv7Rt3Wp5Yb1NcQ4", 3.0) {
+		t.Errorf("IsHighEntropy(%q, 3.0) = false, expected true", "k8Fj2LmQ9zXv7Rt3Wp5Yb1NcQ4")
+	}
+}
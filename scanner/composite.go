@@ -0,0 +1,140 @@
+package scanner
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+// requirement is a compiled config.RuleRequirement
+type requirement struct {
+	pattern *regexp.Regexp
+	within  int
+}
+
+// requirementMatch is a match of a requirement pattern in a file
+type requirementMatch struct {
+	offset int
+	length int
+	RelatedLocation
+}
+
+// compileRequirements compiles the requirements of a composite rule
+func compileRequirements(rule config.Rule) ([]requirement, error) {
+	var requires []requirement
+	for _, req := range rule.Requires {
+		re, err := regexp.Compile(req.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid requires pattern %q for rule %s: %w", req.Pattern, rule.ID, err)
+		}
+		if req.WithinLines < 0 {
+			return nil, fmt.Errorf("invalid requires within_lines %d for rule %s: must not be negative", req.WithinLines, rule.ID)
+		}
+		requires = append(requires, requirement{pattern: re, within: req.WithinLines})
+	}
+	return requires, nil
+}
+
+// resolveComposites pairs the matches of composite rules with their requirements
+// once the whole file has been read. Paired matches are raised one severity level
+// and list the matches they were paired with; unpaired matches are dropped unless
+// the rule reports them.
+func (s *Scanner) resolveComposites(results []Result, lines []string, ctx *fileContext) []Result {
+	composites := make(map[string]*compiledRule)
+	for i := range ctx.rules {
+		if len(ctx.rules[i].requires) > 0 {
+			composites[ctx.rules[i].rule.ID] = &ctx.rules[i]
+		}
+	}
+	if len(composites) == 0 {
+		return results
+	}
+
+	found := make(map[*regexp.Regexp][]requirementMatch)
+	kept := results[:0]
+	for _, result := range results {
+		compiled := composites[result.RuleID]
+		if compiled == nil || result.DetectionType != "regex" {
+			kept = append(kept, result)
+			continue
+		}
+
+		var related []RelatedLocation
+		for _, req := range compiled.requires {
+			matches, ok := found[req.pattern]
+			if !ok {
+				matches = findRequirement(req.pattern, lines)
+				found[req.pattern] = matches
+			}
+			if nearest := nearestRequirement(result, req, matches); nearest != nil {
+				related = append(related, nearest.RelatedLocation)
+			} else {
+				related = nil
+				break
+			}
+		}
+
+		if related == nil {
+			if compiled.rule.ReportUnpaired {
+				kept = append(kept, result)
+			}
+			continue
+		}
+		result.Severity = raiseSeverity(result.Severity)
+		result.Related = related
+		kept = append(kept, result)
+	}
+	return kept
+}
+
+// findRequirement returns every match of a requirement pattern in the file
+func findRequirement(pattern *regexp.Regexp, lines []string) []requirementMatch {
+	var matches []requirementMatch
+	offset := 0
+	for i, line := range lines {
+		for _, loc := range pattern.FindAllStringIndex(line, -1) {
+			if loc[1] == loc[0] {
+				continue
+			}
+			startColumn := utf8.RuneCountInString(line[:loc[0]]) + 1
+			matches = append(matches, requirementMatch{
+				offset: offset + loc[0],
+				length: loc[1] - loc[0],
+				RelatedLocation: RelatedLocation{
+					Line:        i + 1,
+					StartColumn: startColumn,
+					EndColumn:   startColumn + utf8.RuneCountInString(line[loc[0]:loc[1]]),
+					Match:       MaskMatch(line[loc[0]:loc[1]], 4),
+				},
+			})
+		}
+		offset += len(line) + 1
+	}
+	return matches
+}
+
+// nearestRequirement returns the requirement match closest to a result, skipping the
+// result's own span and matches further away than the requirement allows
+func nearestRequirement(result Result, req requirement, matches []requirementMatch) *requirementMatch {
+	var nearest *requirementMatch
+	nearestDistance := 0
+	for i := range matches {
+		match := &matches[i]
+		if match.offset < result.Offset+result.Length && result.Offset < match.offset+match.length {
+			continue
+		}
+		distance := match.Line - result.Line
+		if distance < 0 {
+			distance = -distance
+		}
+		if req.within > 0 && distance > req.within {
+			continue
+		}
+		if nearest == nil || distance < nearestDistance {
+			nearest, nearestDistance = match, distance
+		}
+	}
+	return nearest
+}
@@ -0,0 +1,90 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+func TestScanner_CompositeRules(t *testing.T) {
+	secretRule := config.Rule{
+		ID:       "aws_secret_access_key",
+		Severity: "high",
+		Pattern:  `\b[A-Za-z0-9/+]{40}\b`,
+		Requires: []config.RuleRequirement{{Pattern: `AKIA[0-9A-Z]{16}`, WithinLines: 2}},
+	}
+	secret := "wJalrXUtnFEMI/K7MDENG/bPxRfiCYzQ8h3Tk2Lm"
+
+	tests := []struct {
+		name           string
+		content        string
+		reportUnpaired bool
+		severity       string
+		relatedLine    int
+	}{
+		{
+			name:        "paired within range",
+			content:     "aws_access_key_id = AKIAZ7Q4M2XW9LRT5BNC\naws_secret_access_key = " + secret + "\n",
+			severity:    "critical",
+			relatedLine: 1,
+		},
+		{
+			name:    "requirement too far away",
+			content: "aws_access_key_id = AKIAZ7Q4M2XW9LRT5BNC\n\n\n\naws_secret_access_key = " + secret + "\n",
+		},
+		{
+			name:    "requirement missing",
+			content: "aws_secret_access_key = " + secret + "\n",
+		},
+		{
+			name:           "unpaired match reported at rule severity",
+			content:        "aws_secret_access_key = " + secret + "\n",
+			reportUnpaired: true,
+			severity:       "high",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFile := filepath.Join(t.TempDir(), "credentials")
+			if err := os.WriteFile(testFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+
+			rule := secretRule
+			rule.ReportUnpaired = tt.reportUnpaired
+			scnr, err := NewScanner(&config.Config{Rules: []config.Rule{rule}, EntropyThreshold: 10}, []string{})
+			if err != nil {
+				t.Fatalf("Failed to create scanner: %v", err)
+			}
+			results, err := scnr.ScanFile(testFile)
+			if err != nil {
+				t.Fatalf("Failed to scan file: %v", err)
+			}
+
+			if tt.severity == "" {
+				if len(results) != 0 {
+					t.Errorf("ScanFile(%q) = %+v, expected no findings", tt.content, results)
+				}
+				return
+			}
+			if len(results) != 1 {
+				t.Fatalf("ScanFile(%q) found %d results, expected 1: %+v", tt.content, len(results), results)
+			}
+			if results[0].Severity != tt.severity {
+				t.Errorf("ScanFile(%q) severity = %q, expected %q", tt.content, results[0].Severity, tt.severity)
+			}
+			if tt.relatedLine == 0 {
+				if len(results[0].Related) != 0 {
+					t.Errorf("ScanFile(%q) related = %+v, expected none", tt.content, results[0].Related)
+				}
+				return
+			}
+			if len(results[0].Related) != 1 || results[0].Related[0].Line != tt.relatedLine || results[0].Related[0].StartColumn != 21 {
+				t.Errorf("ScanFile(%q) related = %+v, expected line %d column 21", tt.content, results[0].Related, tt.relatedLine)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package scanner
+
+import (
+	"math"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Confidence signal weights. Scores start from a base that depends on how the
+// finding was detected and are adjusted by the context of the secret.
+const (
+	// regexBaseConfidence is the base for rules without a literal prefix; rules
+	// anchored on a literal like "AKIA" score up to regexPrefixBonus higher
+	regexBaseConfidence = 0.6
+	regexPrefixBonus    = 0.3
+	// entropyBaseConfidence is the base for entropy findings at the threshold; each
+	// bit above it adds entropyMarginWeight, up to entropyMarginBonus
+	entropyBaseConfidence = 0.3
+	entropyMarginWeight   = 0.4
+	entropyMarginBonus    = 0.3
+
+	sensitiveKeyBonus   = 0.15
+	configFileBonus     = 0.05
+	docFilePenalty      = 0.1
+	testPathPenalty     = 0.2
+	placeholderPenalty  = 0.4
+	fakeWordPenalty     = 0.15
+	sensitiveKeyContext = 40
+)
+
+// testPathPattern matches paths of tests, fixtures, mocks and examples
+var testPathPattern = regexp.MustCompile(`(?i)(^|/)(tests?|spec|specs|__tests__|fixtures?|testdata|mocks?|examples?|samples?)/|_test\.|\.test\.|\.spec\.|(^|/)test_`)
+
+// configExtensions are file types that usually hold real configuration values
+var configExtensions = map[string]bool{
+	".env": true, ".yml": true, ".yaml": true, ".json": true, ".toml": true, ".ini": true,
+	".cfg": true, ".conf": true, ".properties": true, ".tf": true, ".tfvars": true,
+}
+
+// docExtensions are file types that usually hold documentation
+var docExtensions = map[string]bool{".md": true, ".rst": true, ".txt": true, ".adoc": true}
+
+// fakeWords mark values that are likely test data even if not outright placeholders
+var fakeWords = []string{"test", "fake", "mock", "dummy", "demo", "sample"}
+
+// regexConfidence returns the base confidence of a rule: patterns with a literal
+// prefix (AKIA, ghp_, xoxb-) are more specific than generic key=value patterns
+func regexConfidence(pattern *regexp.Regexp) float64 {
+	prefix, _ := pattern.LiteralPrefix()
+	return regexBaseConfidence + regexPrefixBonus*math.Min(1, float64(len(prefix))/4)
+}
+
+// entropyConfidence returns the base confidence of an entropy finding from its
+// margin over the threshold
+func entropyConfidence(secret string, threshold float64) float64 {
+	margin := CalculateShannonEntropy(secret) - threshold
+	return entropyBaseConfidence + math.Min(entropyMarginBonus, math.Max(0, margin*entropyMarginWeight))
+}
+
+// scoreConfidence adjusts a base confidence by the context of the secret at
+// line[start:] and returns a score between 0 and 1
+func (ctx *fileContext) scoreConfidence(base float64, secret, key, line string, start int) float64 {
+	score := base
+
+	if isSensitiveKey(key) || (start > 0 && isSensitiveKey(line[max(0, start-sensitiveKeyContext):start])) {
+		score += sensitiveKeyBonus
+	}
+
+	name := strings.ToLower(filepath.Base(ctx.relPath))
+	switch {
+	case configExtensions[ctx.ext] || strings.HasPrefix(name, ".env"):
+		score += configFileBonus
+	case docExtensions[ctx.ext]:
+		score -= docFilePenalty
+	}
+	if testPathPattern.MatchString(filepath.ToSlash(ctx.relPath)) {
+		score -= testPathPenalty
+	}
+
+	if PlaceholderReason(secret) != "" {
+		score -= placeholderPenalty
+	} else {
+		lower := strings.ToLower(secret)
+		for _, word := range fakeWords {
+			if strings.Contains(lower, word) {
+				score -= fakeWordPenalty
+				break
+			}
+		}
+	}
+
+	return math.Round(math.Max(0, math.Min(1, score))*100) / 100
+}
@@ -0,0 +1,68 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+func TestRegexConfidence(t *testing.T) {
+	specific := regexConfidence(regexp.MustCompile(`AKIA[0-9A-Z]{16}`))
+	generic := regexConfidence(regexp.MustCompile(`(?i)(api[_-]?key)\s*[:=]\s*([a-z0-9]{20,})`))
+	if specific <= generic {
+		t.Errorf("regexConfidence(AKIA...) = %.2f, expected more than generic %.2f", specific, generic)
+	}
+}
+
+func TestScanner_Confidence(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		EntropyThreshold: 4.0,
+		Rules: []config.Rule{
+			{ID: "aws_access_key", Severity: "high", Pattern: `AKIA[0-9A-Z]{16}`},
+		},
+	}
+	scnr, err := NewScannerWithOptions(cfg, []string{}, Options{Root: tmpDir})
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	scan := func(name, content string) Result {
+		file := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		results, err := scnr.ScanFile(file)
+		if err != nil {
+			t.Fatalf("Failed to scan file: %v", err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("Expected 1 result in %s, got %d: %+v", name, len(results), results)
+		}
+		if results[0].Confidence < 0 || results[0].Confidence > 1 {
+			t.Fatalf("Confidence %.2f out of range", results[0].Confidence)
+		}
+		return results[0]
+	}
+
+	awsInConfig := scan("deploy/prod.env", "AWS_ACCESS_KEY_ID=AKIAZ7Q4M2XW9LRT5BNC\n")
+	awsInTest := scan("tests/aws_test.go", "var id = \"AKIAZ7Q4M2XW9LRT5BNC\"\n")
+	entropyNamed := scan("app.env", "DB_PASSWORD=k8Fj2LmQ9zXv7Rt3Wp5Yb1NcQ4\n")
+	entropyBare := scan("notes.md", "see k8Fj2LmQ9zXv7Rt3Wp5Yb1NcQ4\n")
+
+	if awsInConfig.Confidence <= awsInTest.Confidence {
+		t.Errorf("Expected a key in a config file (%.2f) to score above one in a test (%.2f)", awsInConfig.Confidence, awsInTest.Confidence)
+	}
+	if entropyNamed.Confidence <= entropyBare.Confidence {
+		t.Errorf("Expected a value under a sensitive key (%.2f) to score above a bare token in docs (%.2f)", entropyNamed.Confidence, entropyBare.Confidence)
+	}
+	if awsInConfig.Confidence <= entropyNamed.Confidence {
+		t.Errorf("Expected a specific rule match (%.2f) to score above an entropy match (%.2f)", awsInConfig.Confidence, entropyNamed.Confidence)
+	}
+}
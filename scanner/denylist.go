@@ -0,0 +1,144 @@
+package scanner
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+// loadDenylist reads the denylist files into a map from SHA-256 digest to provider.
+// Relative paths are resolved against root, so scans from a subdirectory find them.
+func loadDenylist(files []config.DenylistFile, root string) (map[string]string, error) {
+	denylist := make(map[string]string)
+	for _, file := range files {
+		path := file.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, path)
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open denylist file: %w", err)
+		}
+
+		lineNum := 0
+		lines := bufio.NewScanner(f)
+		for lines.Scan() {
+			lineNum++
+			fields := strings.Fields(lines.Text())
+			if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+				continue
+			}
+			if !isSHA256Hex(fields[0]) {
+				f.Close()
+				return nil, fmt.Errorf("invalid denylist entry %q in %s:%d: expected a hex-encoded SHA-256 digest", fields[0], file.Path, lineNum)
+			}
+			provider := file.Provider
+			if len(fields) > 1 {
+				provider = fields[1]
+			}
+			denylist[strings.ToLower(fields[0])] = provider
+		}
+		err = lines.Err()
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read denylist file %s: %w", file.Path, err)
+		}
+	}
+	return denylist, nil
+}
+
+// checkDenylist reports the tokens and literals on a line whose digest is denylisted.
+// Denylisted secrets are reported regardless of allowlists, placeholders and inline
+// ignores, and only their digests are ever held in memory.
+func (s *Scanner) checkDenylist(line string, lineNum int, ctx *fileContext, candidates []literal) []Result {
+	if len(s.denylist) == 0 {
+		return nil
+	}
+
+	var results []Result
+	for _, span := range tokenSpans(line, candidates) {
+		token := line[span[0]:span[1]]
+		secretHash := HashSecret(token)
+		provider, denied := s.denylist[secretHash]
+		if !denied {
+			continue
+		}
+
+		description := "Known leaked secret"
+		if provider != "" {
+			description = fmt.Sprintf("Known leaked %s secret", provider)
+		}
+		result := Result{
+			File:          ctx.path,
+			Line:          lineNum,
+			KeyPath:       ctx.keyPathFor(lineNum, token),
+			RuleID:        "denylist",
+			Severity:      "critical",
+			Match:         MaskMatch(token, 4),
+			Description:   description,
+			DetectionType: "denylist",
+			ScannedAt:     time.Now(),
+			Fingerprint:   Fingerprint(s.config.FingerprintKey, "denylist", ctx.relPath, token),
+			SecretHash:    secretHash,
+		}
+		if provider != "" {
+			result.Metadata = map[string]string{"provider": provider}
+		}
+		result.Confidence = 1
+		ctx.setSpan(&result, line, span[0], span[1])
+		results = append(results, result)
+	}
+	return results
+}
+
+// tokenSpans returns the spans of the values on a line to check against the
+// denylist and honeytokens: the entropy candidates, plus every run of token
+// characters so that secrets are found wherever they appear
+func tokenSpans(line string, candidates []literal) [][2]int {
+	var spans [][2]int
+	seen := make(map[[2]int]bool)
+	add := func(start, end int) {
+		span := [2]int{start, end}
+		if start >= 0 && end > start && !seen[span] {
+			seen[span] = true
+			spans = append(spans, span)
+		}
+	}
+
+	for _, candidate := range candidates {
+		start := candidate.start
+		if start < 0 {
+			start = strings.Index(line, candidate.value)
+		}
+		add(start, start+len(candidate.value))
+	}
+
+	for i := 0; i < len(line); {
+		if !isTokenChar(line[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(line) && isTokenChar(line[i]) {
+			i++
+		}
+		// Keep base64 padding, which is not otherwise a token character
+		for i < len(line) && line[i] == '=' && (i+1 == len(line) || !isTokenChar(line[i+1])) {
+			i++
+		}
+		add(start, i)
+	}
+
+	return spans
+}
+
+// isTokenChar reports whether c can be part of a token (base64, hex and most key formats)
+func isTokenChar(c byte) bool {
+	return isIdentifierChar(c) || strings.IndexByte("-+/.~", c) >= 0
+}
@@ -0,0 +1,81 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+func TestTokenSpans(t *testing.T) {
+	line := `export TOKEN=dGVzdA== other:"p4ss.word"`
+	var tokens []string
+	for _, span := range tokenSpans(line, nil) {
+		tokens = append(tokens, line[span[0]:span[1]])
+	}
+
+	expected := []string{"export", "TOKEN", "dGVzdA==", "other", "p4ss.word"}
+	if strings.Join(tokens, " ") != strings.Join(expected, " ") {
+		t.Errorf("tokenSpans(%q) = %q, expected %q", line, tokens, expected)
+	}
+}
+
+func TestScanner_Denylist(t *testing.T) {
+	tmpDir := t.TempDir()
+	leaked := "old-Leaked-Pass-2021"
+	hashFile := filepath.Join(tmpDir, "leaked.txt")
+	hashContent := "# rotated credentials\n" + HashSecret(leaked) + " legacy-db\n" + HashSecret("unused") + "\n"
+	if err := os.WriteFile(hashFile, []byte(hashContent), 0644); err != nil {
+		t.Fatalf("Failed to create hash file: %v", err)
+	}
+
+	testFile := filepath.Join(tmpDir, "settings.py")
+	testContent := "DB_PASSWORD = \"" + leaked + "\"  # leakyrepo:allow\n"
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cfg := &config.Config{
+		EntropyThreshold: 4.5,
+		Allowlist:        config.Allowlist{Strings: []string{"DB_PASSWORD"}},
+		// Relative to the root, not the working directory
+		Denylist: config.Denylist{Files: []config.DenylistFile{{Path: "leaked.txt", Provider: "postgres"}}},
+	}
+	scnr, err := NewScannerWithOptions(cfg, []string{}, Options{Root: tmpDir})
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	results, err := scnr.ScanFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to scan file: %v", err)
+	}
+
+	// Neither the allowlisted line nor the inline ignore hides a denylisted secret
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d: %+v", len(results), results)
+	}
+	result := results[0]
+	if result.Severity != "critical" || result.RuleID != "denylist" {
+		t.Errorf("Expected a critical denylist result, got %q (%s)", result.RuleID, result.Severity)
+	}
+	if result.Metadata["provider"] != "legacy-db" {
+		t.Errorf("Expected the per-hash provider to override the file provider, got %q", result.Metadata["provider"])
+	}
+	if got := testContent[result.Offset : result.Offset+result.Length]; got != leaked {
+		t.Errorf("Expected the span to cover the leaked secret, got %q", got)
+	}
+}
+
+func TestLoadDenylist_InvalidEntry(t *testing.T) {
+	tmpDir := t.TempDir()
+	hashFile := filepath.Join(tmpDir, "leaked.txt")
+	if err := os.WriteFile(hashFile, []byte("not-a-hash\n"), 0644); err != nil {
+		t.Fatalf("Failed to create hash file: %v", err)
+	}
+
+	if _, err := loadDenylist([]config.DenylistFile{{Path: hashFile}}, tmpDir); err == nil {
+		t.Error("Expected an error for an invalid denylist entry")
+	}
+}
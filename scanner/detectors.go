@@ -0,0 +1,39 @@
+package scanner
+
+// detection is a secret found by a built-in detector
+type detection struct {
+	// start and end delimit the secret within the line
+	start, end int
+	// secret is the decoded secret value, used for allowlists and fingerprints
+	secret string
+	// text is the full credential-bearing text (e.g. the URL), used for allowlist strings
+	text string
+	// match is shown in place of the text, with the secret masked
+	match       string
+	description string
+	// severity overrides the detector's severity when set
+	severity string
+	// metadata holds details decoded from the secret (e.g. JWT claims)
+	metadata map[string]string
+}
+
+// builtinDetector finds secrets that need parsing rather than a single regex
+type builtinDetector struct {
+	id       string
+	severity string
+	detect   func(line string) []detection
+	// confidence is the base confidence of the detector's findings
+	confidence float64
+	// classification is copied to the results (e.g. "pii")
+	classification string
+	// piiType is the PII type for PII detectors
+	piiType string
+	// tags group the detector with rules for selection on the command line
+	tags []string
+}
+
+// builtinDetectors run on every line in addition to the configured rules
+var builtinDetectors = []builtinDetector{
+	{id: "url_credentials", severity: "high", detect: detectURLCredentials, confidence: 0.85, tags: []string{"builtin", "generic"}},
+	{id: "jwt", severity: "high", detect: detectJWTs, confidence: 0.8, tags: []string{"builtin", "generic"}},
+}
@@ -89,10 +89,8 @@ func IsHighEntropy(s string, threshold float64) bool {
 		return false
 	}
 	
-	// Variable names with common prefixes/suffixes
-	if strings.HasPrefix(s, "play") || strings.HasPrefix(s, "Drop") || 
-	   strings.HasSuffix(s, "Name") || strings.HasSuffix(s, "DATE") || 
-	   strings.HasSuffix(s, "NGED") {
+	// Identifiers, words, file paths and URLs are structured text, not secrets
+	if classifyToken(s) != "" {
 		return false
 	}
 
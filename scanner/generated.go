@@ -0,0 +1,85 @@
+package scanner
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// lockFiles are dependency lockfiles, which hold checksums and resolved URLs
+// rather than hand-written values
+var lockFiles = map[string]bool{
+	"package-lock.json":   true,
+	"npm-shrinkwrap.json": true,
+	"yarn.lock":           true,
+	"pnpm-lock.yaml":      true,
+	"bun.lockb":           true,
+	"cargo.lock":          true,
+	"go.sum":              true,
+	"gemfile.lock":        true,
+	"composer.lock":       true,
+	"poetry.lock":         true,
+	"pipfile.lock":        true,
+	"pdm.lock":            true,
+	"uv.lock":             true,
+	"packages.lock.json":  true,
+	"podfile.lock":        true,
+	"pubspec.lock":        true,
+	"mix.lock":            true,
+	"flake.lock":          true,
+	".terraform.lock.hcl": true,
+}
+
+// generatedHeaderPattern matches the "Code generated ... DO NOT EDIT." convention
+// (https://go.dev/s/generatedcode) and the @generated marker used by other tools
+var generatedHeaderPattern = regexp.MustCompile(`(?i)\bcode generated\b.*\bdo not edit\b|@generated\b`)
+
+const (
+	// generatedHeaderBytes is how much of a file is searched for a generated header
+	generatedHeaderBytes = 1024
+	// minifiedLineLength is the average line length above which a file is treated
+	// as minified
+	minifiedLineLength = 300
+	// minifiedMinBytes keeps short one-line files from being treated as minified
+	minifiedMinBytes = 1024
+)
+
+// generatedFileKind classifies a file by name as a lockfile, source map or minified
+// bundle, and returns "" for other files
+func generatedFileKind(filePath string) string {
+	name := strings.ToLower(filepath.Base(filePath))
+	switch {
+	case lockFiles[name]:
+		return "lockfile"
+	case strings.HasSuffix(name, ".map"):
+		return "source map"
+	case strings.HasSuffix(name, ".min.js") || strings.HasSuffix(name, ".min.css") || strings.HasSuffix(name, ".min.mjs"):
+		return "minified"
+	}
+	return ""
+}
+
+// isMinified reports whether content looks like a minified bundle, judging by its
+// average line length
+func isMinified(content []byte) bool {
+	if len(content) < minifiedMinBytes {
+		return false
+	}
+	lines := bytes.Count(bytes.TrimRight(content, "\n"), []byte("\n")) + 1
+	return len(content)/lines > minifiedLineLength
+}
+
+// hasGeneratedHeader reports whether content starts with a code generator marker
+func hasGeneratedHeader(content []byte) bool {
+	if len(content) > generatedHeaderBytes {
+		content = content[:generatedHeaderBytes]
+	}
+	return generatedHeaderPattern.Match(content)
+}
+
+// scanGenerated reports whether generated, minified and lock files are scanned like
+// any other file
+func (s *Scanner) scanGenerated() bool {
+	return s.config.ScanGenerated || s.options.ScanGenerated
+}
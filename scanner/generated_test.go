@@ -0,0 +1,138 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+func TestGeneratedFileKind(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"package-lock.json", "lockfile"},
+		{"web/yarn.lock", "lockfile"},
+		{"Cargo.lock", "lockfile"},
+		{"dist/app.js.map", "source map"},
+		{"static/vendor.min.js", "minified"},
+		{"static/site.min.css", "minified"},
+		{"package.json", ""},
+		{"src/lock.go", ""},
+		{"app.js", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := generatedFileKind(tt.path); got != tt.expected {
+				t.Errorf("generatedFileKind(%q) = %q, expected %q", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsMinified(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected bool
+	}{
+		{"one long line", strings.Repeat("var a=1;", 500), true},
+		{"long lines", strings.Repeat(strings.Repeat("x", 400)+"\n", 5), true},
+		{"source code", strings.Repeat("func main() {\n\tfmt.Println(\"hi\")\n}\n", 100), false},
+		{"short file", "var a=1;var b=2;", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isMinified([]byte(tt.content)); got != tt.expected {
+				t.Errorf("isMinified(%q...) = %v, expected %v", tt.content[:10], got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHasGeneratedHeader(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected bool
+	}{
+		{"// Code generated by protoc-gen-go. DO NOT EDIT.\npackage pb\n", true},
+		{"# Code generated by sqlc. DO NOT EDIT.\n", true},
+		{"/* @generated by Relay */\n", true},
+		{"package main\n\n// Generated values below\n", false},
+		{strings.Repeat("\n", 2000) + "// Code generated by hand. DO NOT EDIT.\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.TrimSpace(tt.content), func(t *testing.T) {
+			if got := hasGeneratedHeader([]byte(tt.content)); got != tt.expected {
+				t.Errorf("hasGeneratedHeader(%q) = %v, expected %v", strings.TrimSpace(tt.content), got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScanner_GeneratedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	generated := filepath.Join(tmpDir, "api.pb.go")
+	content := "// Code generated by protoc-gen-go. DO NOT EDIT.\n" +
+		"var fileDescriptor = \"k8Fj2LmQ9zXv7Rt3Wp5Yb1NcQ4x\"\n" +
+		"var key = \"AKIAZ7Q4M2XW9LRT5BNC\"\n"
+	if err := os.WriteFile(generated, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	lockfile := filepath.Join(tmpDir, "yarn.lock")
+	if err := os.WriteFile(lockfile, []byte("resolved \"https://registry.example.org/pkg?key=AKIAZ7Q4M2XW9LRT5BNC\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	// A compact one-line JSON file looks minified, but is not named like a bundle
+	compact := filepath.Join(tmpDir, "svc.json")
+	content = `{"aws_key":"AKIAZ7Q4M2XW9LRT5BNC","regions":[` + strings.Repeat(`"eu-west-1",`, 200) + `"us-east-1"]}`
+	if err := os.WriteFile(compact, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cfg := &config.Config{
+		EntropyThreshold: 4.0,
+		Rules: []config.Rule{
+			{ID: "aws_access_key", Severity: "high", Pattern: `AKIA[0-9A-Z]{16}`},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		opts     Options
+		file     string
+		expected []string
+	}{
+		{"generated code is checked without entropy", Options{}, generated, []string{"regex"}},
+		{"lockfile is skipped", Options{}, lockfile, nil},
+		{"minified content is checked without entropy", Options{}, compact, []string{"regex"}},
+		{"override scans generated code", Options{ScanGenerated: true}, generated, []string{"entropy", "regex"}},
+		{"override scans lockfile", Options{ScanGenerated: true}, lockfile, []string{"regex"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scnr, err := NewScannerWithOptions(cfg, []string{}, tt.opts)
+			if err != nil {
+				t.Fatalf("Failed to create scanner: %v", err)
+			}
+			results, err := scnr.ScanFile(tt.file)
+			if err != nil {
+				t.Fatalf("Failed to scan file: %v", err)
+			}
+			var types []string
+			for _, result := range results {
+				types = append(types, result.DetectionType)
+			}
+			if strings.Join(types, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("ScanFile(%q) detection types = %v, expected %v", filepath.Base(tt.file), types, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package scanner
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+// ClassificationCanary marks findings that are planted honeytokens rather than leaks
+const ClassificationCanary = "canary"
+
+// awsKeyIDPattern matches AWS access key IDs that encode an account ID
+var awsKeyIDPattern = regexp.MustCompile(`^(AKIA|ASIA)[A-Z2-7]{16}$`)
+
+// honeytoken is a compiled config.Honeytoken
+type honeytoken struct {
+	config.Honeytoken
+	account *regexp.Regexp
+}
+
+// compileHoneytokens validates the configured honeytokens
+func compileHoneytokens(configs []config.Honeytoken) ([]honeytoken, error) {
+	var honeytokens []honeytoken
+	for _, cfg := range configs {
+		if cfg.Prefix == "" && cfg.Hash == "" && cfg.AWSAccountID == "" {
+			return nil, fmt.Errorf("honeytoken %q needs a prefix, hash or aws_account_id", cfg.Name)
+		}
+		if cfg.Hash != "" && !isSHA256Hex(cfg.Hash) {
+			return nil, fmt.Errorf("invalid hash for honeytoken %q: expected a hex-encoded SHA-256 digest", cfg.Name)
+		}
+		compiled := honeytoken{Honeytoken: cfg}
+		compiled.Hash = strings.ToLower(cfg.Hash)
+		if cfg.AWSAccountID != "" {
+			re, err := regexp.Compile("^(?:" + cfg.AWSAccountID + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("invalid aws_account_id pattern for honeytoken %q: %w", cfg.Name, err)
+			}
+			compiled.account = re
+		}
+		honeytokens = append(honeytokens, compiled)
+	}
+	return honeytokens, nil
+}
+
+// matches reports whether a token (with its SHA-256 digest) is this honeytoken
+func (h *honeytoken) matches(token, tokenHash string) bool {
+	if h.Prefix != "" && len(token) > len(h.Prefix) && strings.HasPrefix(token, h.Prefix) {
+		return true
+	}
+	if h.Hash != "" && h.Hash == tokenHash {
+		return true
+	}
+	if h.account != nil {
+		if account, ok := awsAccountID(token); ok && h.account.MatchString(account) {
+			return true
+		}
+	}
+	return false
+}
+
+// awsAccountID decodes the 12-digit account ID encoded in an AWS access key ID
+func awsAccountID(keyID string) (string, bool) {
+	if !awsKeyIDPattern.MatchString(keyID) {
+		return "", false
+	}
+	decoded, err := base32.StdEncoding.DecodeString(keyID[4:])
+	if err != nil || len(decoded) < 6 {
+		return "", false
+	}
+	// The account ID is stored in bits 1-40 of the first six bytes
+	var buf [8]byte
+	copy(buf[2:], decoded[:6])
+	value := binary.BigEndian.Uint64(buf[:])
+	return fmt.Sprintf("%012d", (value&0x7fffffffff80)>>7), true
+}
+
+// checkHoneytokens reports the tokens on a line that are declared honeytokens,
+// skipping spans already reported
+func (s *Scanner) checkHoneytokens(line string, lineNum int, ctx *fileContext, candidates []literal, reported []Result) []Result {
+	if len(s.honeytokens) == 0 {
+		return nil
+	}
+
+	var results []Result
+	for _, span := range tokenSpans(line, candidates) {
+		if ctx.overlapsAny(reported, span[0], span[1]) || ctx.overlapsAny(results, span[0], span[1]) {
+			continue
+		}
+		token := line[span[0]:span[1]]
+		tokenHash := HashSecret(token)
+		for i := range s.honeytokens {
+			h := &s.honeytokens[i]
+			if !h.matches(token, tokenHash) {
+				continue
+			}
+			result := Result{
+				File:           ctx.path,
+				Line:           lineNum,
+				KeyPath:        ctx.keyPathFor(lineNum, token),
+				RuleID:         "honeytoken",
+				Severity:       "low",
+				Match:          MaskMatch(token, 4),
+				Description:    fmt.Sprintf("Canary token %q (planted on purpose)", h.Name),
+				DetectionType:  "honeytoken",
+				Classification: ClassificationCanary,
+				Metadata:       map[string]string{"honeytoken": h.Name},
+				ScannedAt:      time.Now(),
+				Fingerprint:    Fingerprint(s.config.FingerprintKey, "honeytoken", ctx.relPath, token),
+				SecretHash:     tokenHash,
+			}
+			result.Confidence = 1
+			ctx.setSpan(&result, line, span[0], span[1])
+			results = append(results, result)
+			break
+		}
+	}
+	return results
+}
+
+// CanaryStatus reports where a honeytoken was found
+type CanaryStatus struct {
+	// Name is the honeytoken name
+	Name string
+	// Found are the files (relative to the root) where the honeytoken was found
+	Found []string
+	// Missing are the expected locations where the honeytoken was not found; for a
+	// honeytoken without locations, it is "*" if the honeytoken was not found anywhere
+	Missing []string
+}
+
+// CheckCanaries compares the honeytokens found in results with where they are
+// expected to be planted. Paths are matched relative to root.
+func CheckCanaries(honeytokens []config.Honeytoken, results []Result, root string) []CanaryStatus {
+	var statuses []CanaryStatus
+	for _, h := range honeytokens {
+		status := CanaryStatus{Name: h.Name}
+		seen := make(map[string]bool)
+		for _, result := range results {
+			if result.Classification != ClassificationCanary || result.Metadata["honeytoken"] != h.Name {
+				continue
+			}
+			relPath := result.File
+			if rel, err := filepath.Rel(root, result.File); err == nil && !strings.HasPrefix(rel, "..") {
+				relPath = filepath.ToSlash(rel)
+			}
+			if !seen[relPath] {
+				seen[relPath] = true
+				status.Found = append(status.Found, relPath)
+			}
+		}
+		sort.Strings(status.Found)
+
+		if len(h.Locations) == 0 && len(status.Found) == 0 {
+			status.Missing = []string{"*"}
+		}
+		for _, location := range h.Locations {
+			found := false
+			for _, relPath := range status.Found {
+				if matchPathPattern(location, relPath) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				status.Missing = append(status.Missing, location)
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
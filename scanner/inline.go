@@ -0,0 +1,87 @@
+package scanner
+
+import (
+	"regexp"
+	"strings"
+)
+
+// inlineDirectivePattern matches "leakyrepo:allow" markers in any comment syntax,
+// capturing the optional key=value arguments that follow
+var inlineDirectivePattern = regexp.MustCompile(`leakyrepo:allow\b(.*)`)
+
+// inlineArgPattern matches key=value, key="value" and key='value' arguments
+var inlineArgPattern = regexp.MustCompile(`(\w+)=(?:"([^"]*)"|'([^']*)'|([^\s"']+))`)
+
+// inlineDirective is a parsed "leakyrepo:allow" marker
+type inlineDirective struct {
+	// rules limits the directive to specific rule IDs (empty means all findings)
+	rules []string
+	// reason is the justification given in the marker
+	reason string
+}
+
+// parseInlineDirective returns the directive on a line, or nil if there is none.
+// Supported forms:
+//
+//	leakyrepo:allow
+//	leakyrepo:allow rule=aws_access_key reason="fixture"
+//	leakyrepo:allow rule=aws_access_key,generic_api_key
+func parseInlineDirective(line string) *inlineDirective {
+	if !strings.Contains(line, "leakyrepo:allow") {
+		return nil
+	}
+	m := inlineDirectivePattern.FindStringSubmatch(line)
+	if m == nil {
+		return nil
+	}
+
+	directive := &inlineDirective{}
+	for _, arg := range inlineArgPattern.FindAllStringSubmatch(m[1], -1) {
+		value := arg[2] + arg[3] + arg[4]
+		switch strings.ToLower(arg[1]) {
+		case "rule", "rules":
+			for _, rule := range strings.Split(value, ",") {
+				if rule = strings.TrimSpace(rule); rule != "" {
+					directive.rules = append(directive.rules, rule)
+				}
+			}
+		case "reason":
+			directive.reason = value
+		}
+	}
+
+	return directive
+}
+
+// commentMarkerChars are the characters of line comment and block comment markers
+// (#, //, /*, *, --, ;, <!--, %, ')
+const commentMarkerChars = "#/*-;<!%'"
+
+// parseStandaloneDirective returns the directive on a line that holds nothing but a
+// comment, or nil. A directive trailing code on the same line covers only that line,
+// so it must not carry over to the next one.
+func parseStandaloneDirective(line string) *inlineDirective {
+	index := strings.Index(line, "leakyrepo:allow")
+	if index < 0 || strings.Trim(line[:index], commentMarkerChars+" \t") != "" {
+		return nil
+	}
+	return parseInlineDirective(line)
+}
+
+// covers reports whether the directive suppresses a result. Findings without a
+// rule ID are referenced by their detection type (e.g. rule=entropy).
+func (d *inlineDirective) covers(result Result) bool {
+	if len(d.rules) == 0 {
+		return true
+	}
+	id := result.RuleID
+	if id == "" {
+		id = result.DetectionType
+	}
+	for _, rule := range d.rules {
+		if rule == id {
+			return true
+		}
+	}
+	return false
+}
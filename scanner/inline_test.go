@@ -0,0 +1,165 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+func TestParseInlineDirective(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected *inlineDirective
+	}{
+		{
+			name:     "no directive",
+			line:     `key := "value"`,
+			expected: nil,
+		},
+		{
+			name:     "bare directive",
+			line:     `token = "abc" # leakyrepo:allow`,
+			expected: &inlineDirective{},
+		},
+		{
+			name:     "rule and quoted reason",
+			line:     `// leakyrepo:allow rule=aws_access_key reason="test fixture"`,
+			expected: &inlineDirective{rules: []string{"aws_access_key"}, reason: "test fixture"},
+		},
+		{
+			name:     "multiple rules in block comment",
+			line:     `/* leakyrepo:allow rule=aws_access_key,entropy reason='docs' */`,
+			expected: &inlineDirective{rules: []string{"aws_access_key", "entropy"}, reason: "docs"},
+		},
+		{
+			name:     "similar word is not a directive",
+			line:     `# leakyrepo:allowed`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseInlineDirective(tt.line)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("parseInlineDirective(%q) = %+v, expected %+v", tt.line, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseStandaloneDirective(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected bool
+	}{
+		{`# leakyrepo:allow`, true},
+		{`    // leakyrepo:allow rule=aws_access_key`, true},
+		{`<!-- leakyrepo:allow -->`, true},
+		{`-- leakyrepo:allow`, true},
+		{`x = 1 # leakyrepo:allow`, false},
+		{`token = "abc" // leakyrepo:allow`, false},
+		{`x = 1`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			if got := parseStandaloneDirective(tt.line) != nil; got != tt.expected {
+				t.Errorf("parseStandaloneDirective(%q) != nil = %v, expected %v", tt.line, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScanner_InlineIgnores(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "keys.py")
+	testContent := `a = "AKIAZ7Q4M2XW9LRT5BNC"  # leakyrepo:allow reason="revoked"
+# leakyrepo:allow rule=aws_access_key
+b = "AKIAQ3J8M2XW9LRT5BNC"
+# leakyrepo:allow rule=generic_api_key
+c = "AKIAR5K8M2XW9LRT5BNC"
+`
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cfg := &config.Config{
+		EntropyThreshold: 8.0,
+		Rules: []config.Rule{
+			{
+				ID:       "aws_access_key",
+				Severity: "high",
+				Pattern:  `AKIA[0-9A-Z]{16}`,
+			},
+		},
+	}
+
+	scnr, err := NewScanner(cfg, []string{})
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	results, err := scnr.ScanFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to scan file: %v", err)
+	}
+	if len(results) != 1 || results[0].Line != 5 {
+		t.Errorf("Expected a single result on line 5, got %+v", results)
+	}
+
+	suppressed := scnr.Suppressed()
+	if len(suppressed) != 2 {
+		t.Fatalf("Expected 2 suppressed results, got %d", len(suppressed))
+	}
+	if suppressed[0].Suppression != "inline" || suppressed[0].SuppressionReason != "revoked" {
+		t.Errorf("Unexpected suppression info: %q %q", suppressed[0].Suppression, suppressed[0].SuppressionReason)
+	}
+
+	// Inline ignores can be disabled, e.g. in CI
+	scnr, err = NewScannerWithOptions(cfg, []string{}, Options{NoInlineIgnores: true})
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	results, err = scnr.ScanFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to scan file: %v", err)
+	}
+	if len(results) != 3 {
+		t.Errorf("Expected 3 results with inline ignores disabled, got %d", len(results))
+	}
+}
+
+func TestScanner_TrailingDirective(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "keys.py")
+	testContent := `x = 1  # leakyrepo:allow
+b = "AKIAQ3J8M2XW9LRT5BNC"
+`
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cfg := &config.Config{
+		EntropyThreshold: 8.0,
+		Rules: []config.Rule{
+			{ID: "aws_access_key", Severity: "high", Pattern: `AKIA[0-9A-Z]{16}`},
+		},
+	}
+	scnr, err := NewScanner(cfg, []string{})
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	// A directive trailing code covers its own line, not the next one
+	results, err := scnr.ScanFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to scan file: %v", err)
+	}
+	if len(results) != 1 || results[0].Line != 2 {
+		t.Errorf("Expected a single result on line 2, got %+v", results)
+	}
+}
@@ -0,0 +1,96 @@
+package scanner
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// jwtPattern matches the three base64url segments of a JWT. Both the header and
+// the claims are JSON objects, so they start with "eyJ" ({"); the signature is
+// empty for unsigned tokens.
+var jwtPattern = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{5,}\.eyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]*`)
+
+// jwtNow returns the time used to decide whether a token has expired
+var jwtNow = time.Now
+
+// detectJWTs finds JSON Web Tokens and decodes their header and claims without
+// verifying the signature. Expired and unsigned (alg "none") tokens are reported
+// with low severity, since they cannot be used to authenticate.
+func detectJWTs(line string) []detection {
+	var detections []detection
+	for _, loc := range jwtPattern.FindAllStringIndex(line, -1) {
+		token := line[loc[0]:loc[1]]
+		parts := strings.Split(token, ".")
+
+		var header struct {
+			Alg string `json:"alg"`
+		}
+		var claims map[string]interface{}
+		if !decodeJWTSegment(parts[0], &header) || header.Alg == "" || !decodeJWTSegment(parts[1], &claims) {
+			continue
+		}
+
+		metadata := map[string]string{"alg": header.Alg}
+		for _, claim := range []string{"iss", "sub", "aud"} {
+			if value := jwtClaimString(claims[claim]); value != "" {
+				metadata[claim] = value
+			}
+		}
+
+		found := detection{
+			start:       loc[0],
+			end:         loc[1],
+			secret:      token,
+			text:        token,
+			match:       MaskMatch(token, 4),
+			description: "JSON Web Token",
+			metadata:    metadata,
+		}
+
+		if exp, ok := claims["exp"].(float64); ok {
+			expiry := time.Unix(int64(exp), 0).UTC()
+			metadata["exp"] = expiry.Format(time.RFC3339)
+			if expiry.Before(jwtNow()) {
+				found.severity = "low"
+				found.description = fmt.Sprintf("Expired JSON Web Token (expired %s)", expiry.Format("2006-01-02"))
+			}
+		}
+		if strings.EqualFold(header.Alg, "none") {
+			found.severity = "low"
+			found.description = "Unsigned JSON Web Token (alg: none)"
+		}
+
+		detections = append(detections, found)
+	}
+	return detections
+}
+
+// decodeJWTSegment decodes a base64url-encoded JSON segment of a JWT
+func decodeJWTSegment(segment string, v interface{}) bool {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+// jwtClaimString formats a string or string array claim (aud may be either)
+func jwtClaimString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return strings.Join(values, ",")
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+package scanner
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+// makeJWT builds an unverified token from JSON header and claims
+func makeJWT(header, claims, signature string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(header)) + "." + enc.EncodeToString([]byte(claims)) + "." + signature
+}
+
+func TestDetectJWTs(t *testing.T) {
+	jwtNow = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+	defer func() { jwtNow = time.Now }()
+
+	live := makeJWT(`{"alg":"HS256","typ":"JWT"}`, `{"iss":"auth.internal","sub":"svc-billing","aud":["api","admin"],"exp":1893456000}`, "c2lnbmF0dXJlLXZhbHVl")
+	expired := makeJWT(`{"alg":"RS256"}`, `{"sub":"test-user","exp":1577836800}`, "c2lnbmF0dXJl")
+	unsigned := makeJWT(`{"alg":"none"}`, `{"sub":"admin"}`, "")
+
+	tests := []struct {
+		name     string
+		line     string
+		found    bool
+		severity string
+		metadata map[string]string
+	}{
+		{
+			name:     "live token",
+			line:     `Authorization: Bearer ` + live,
+			found:    true,
+			metadata: map[string]string{"alg": "HS256", "iss": "auth.internal", "sub": "svc-billing", "aud": "api,admin", "exp": "2030-01-01T00:00:00Z"},
+		},
+		{
+			name:     "expired token",
+			line:     `token = "` + expired + `"`,
+			found:    true,
+			severity: "low",
+			metadata: map[string]string{"alg": "RS256", "sub": "test-user", "exp": "2020-01-01T00:00:00Z"},
+		},
+		{
+			name:     "unsigned token",
+			line:     unsigned,
+			found:    true,
+			severity: "low",
+			metadata: map[string]string{"alg": "none", "sub": "admin"},
+		},
+		{
+			name: "not json",
+			line: "eyJhbGciOiJIUzI1.eyJzdWIiOiIxMjM0.abc",
+		},
+		{
+			name: "two segments",
+			line: "eyJhbGciOiJIUzI1NiJ9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detections := detectJWTs(tt.line)
+			if (len(detections) == 1) != tt.found {
+				t.Fatalf("detectJWTs(%q) found %d tokens, expected found = %v", tt.line, len(detections), tt.found)
+			}
+			if !tt.found {
+				return
+			}
+			found := detections[0]
+			if found.severity != tt.severity {
+				t.Errorf("detectJWTs(%q) severity = %q, expected %q", tt.line, found.severity, tt.severity)
+			}
+			if len(found.metadata) != len(tt.metadata) {
+				t.Errorf("detectJWTs(%q) metadata = %v, expected %v", tt.line, found.metadata, tt.metadata)
+			}
+			for key, value := range tt.metadata {
+				if found.metadata[key] != value {
+					t.Errorf("detectJWTs(%q) metadata[%q] = %q, expected %q", tt.line, key, found.metadata[key], value)
+				}
+			}
+			if tt.line[found.start:found.end] != found.secret {
+				t.Errorf("detectJWTs(%q) span %d-%d does not cover the token", tt.line, found.start, found.end)
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package scanner
+
+import (
+	"strings"
+)
+
+// literal is a string literal value (without its quotes) found on a line
+type literal struct {
+	value string
+	// start is the byte offset of the value within the line (-1 if unknown)
+	start int
+	// keyPath and key are set for values from structured files
+	keyPath string
+	key     string
+}
+
+// quoteSyntax describes one kind of string literal delimiter
+type quoteSyntax struct {
+	delim string
+	// raw literals have no backslash escapes
+	raw bool
+	// multiline literals may span lines
+	multiline bool
+	// interpolation starts an embedded expression that is not part of the literal (e.g. "${")
+	interpolation string
+}
+
+// languageSyntax describes the lexical elements needed to find string literals
+type languageSyntax struct {
+	lineComments []string
+	blockComment [2]string
+	// quotes are checked in order, so longer delimiters must come first
+	quotes []quoteSyntax
+	// rawPrefixes are identifier characters that may prefix a quote; "r" or "R" makes it raw (Python)
+	rawPrefixes string
+	// shell enables unquoted VAR=value assignments and word-start-only comments
+	shell bool
+}
+
+var (
+	goSyntax = &languageSyntax{
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes: []quoteSyntax{
+			{delim: "`", raw: true, multiline: true},
+			{delim: `"`},
+			{delim: "'"},
+		},
+	}
+	jsSyntax = &languageSyntax{
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes: []quoteSyntax{
+			{delim: "`", multiline: true, interpolation: "${"},
+			{delim: `"`},
+			{delim: "'"},
+		},
+	}
+	pythonSyntax = &languageSyntax{
+		lineComments: []string{"#"},
+		quotes: []quoteSyntax{
+			{delim: `"""`, multiline: true},
+			{delim: "'''", multiline: true},
+			{delim: `"`},
+			{delim: "'"},
+		},
+		rawPrefixes: "rRbBuUfF",
+	}
+	javaSyntax = &languageSyntax{
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes: []quoteSyntax{
+			{delim: `"""`, multiline: true},
+			{delim: `"`},
+			{delim: "'"},
+		},
+	}
+	rubySyntax = &languageSyntax{
+		lineComments: []string{"#"},
+		quotes: []quoteSyntax{
+			{delim: `"`, multiline: true, interpolation: "#{"},
+			{delim: "'", multiline: true},
+		},
+	}
+	shellSyntax = &languageSyntax{
+		lineComments: []string{"#"},
+		quotes: []quoteSyntax{
+			{delim: `"`, multiline: true, interpolation: "$("},
+			{delim: "'", raw: true, multiline: true},
+		},
+		shell: true,
+	}
+)
+
+// syntaxByExtension maps file extensions to the language used for literal extraction
+var syntaxByExtension = map[string]*languageSyntax{
+	".go":   goSyntax,
+	".js":   jsSyntax,
+	".jsx":  jsSyntax,
+	".mjs":  jsSyntax,
+	".cjs":  jsSyntax,
+	".ts":   jsSyntax,
+	".tsx":  jsSyntax,
+	".py":   pythonSyntax,
+	".java": javaSyntax,
+	".kt":   javaSyntax,
+	".rb":   rubySyntax,
+	".sh":   shellSyntax,
+	".bash": shellSyntax,
+	".zsh":  shellSyntax,
+}
+
+// literalLexer extracts string literals line by line, carrying multi-line
+// strings and block comments over from one line to the next
+type literalLexer struct {
+	syntax         *languageSyntax
+	inBlockComment bool
+	// open is the string literal continuing from the previous line, if any
+	open *quoteSyntax
+	raw  bool
+}
+
+// newLiteralLexer returns a lexer for the file extension, or nil if the language is not supported
+func newLiteralLexer(ext string) *literalLexer {
+	syntax, ok := syntaxByExtension[ext]
+	if !ok {
+		return nil
+	}
+	return &literalLexer{syntax: syntax}
+}
+
+// lexLine returns the string literals and the comment text on the next line of the
+// file. Literals and block comments that span lines are returned as one segment per line.
+func (l *literalLexer) lexLine(line string) (literals, comments []literal) {
+	emit := func(start, end int) {
+		if end > start {
+			literals = append(literals, literal{value: line[start:end], start: start})
+		}
+	}
+	comment := func(start, end int) {
+		if end > start {
+			comments = append(comments, literal{value: line[start:end], start: start})
+		}
+	}
+
+	segStart := 0
+	i := 0
+	for i < len(line) {
+		switch {
+		case l.inBlockComment:
+			end := strings.Index(line[i:], l.syntax.blockComment[1])
+			if end < 0 {
+				comment(i, len(line))
+				return literals, comments
+			}
+			comment(i, i+end)
+			i += end + len(l.syntax.blockComment[1])
+			l.inBlockComment = false
+
+		case l.open != nil:
+			if line[i] == '\\' && !l.raw {
+				i += 2
+				continue
+			}
+			if interp := l.open.interpolation; interp != "" && strings.HasPrefix(line[i:], interp) {
+				emit(segStart, i)
+				i = skipInterpolation(line, i+len(interp))
+				segStart = i
+				continue
+			}
+			if strings.HasPrefix(line[i:], l.open.delim) {
+				emit(segStart, i)
+				i += len(l.open.delim)
+				l.open = nil
+				continue
+			}
+			i++
+
+		default:
+			if l.startsComment(line, i) {
+				comment(i, len(line))
+				return literals, comments
+			}
+			if start := l.syntax.blockComment[0]; start != "" && strings.HasPrefix(line[i:], start) {
+				l.inBlockComment = true
+				i += len(start)
+				continue
+			}
+			if quote := l.matchQuote(line, i); quote != nil {
+				l.open = quote
+				l.raw = quote.raw || l.hasRawPrefix(line, i)
+				i += len(quote.delim)
+				segStart = i
+				continue
+			}
+			if l.syntax.shell {
+				if start, end := shellAssignmentValue(line, i); end > start {
+					emit(start, end)
+					i = end
+					continue
+				}
+			}
+			i++
+		}
+	}
+
+	if l.open != nil {
+		emit(segStart, len(line))
+		if !l.open.multiline {
+			// Unterminated single-line string; resynchronize on the next line
+			l.open = nil
+		}
+	}
+
+	return literals, comments
+}
+
+// startsComment reports whether a line comment starts at position i
+func (l *literalLexer) startsComment(line string, i int) bool {
+	for _, marker := range l.syntax.lineComments {
+		if !strings.HasPrefix(line[i:], marker) {
+			continue
+		}
+		// In shell, # only starts a comment at the beginning of a word
+		if l.syntax.shell && i > 0 && line[i-1] != ' ' && line[i-1] != '\t' && line[i-1] != ';' {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// matchQuote returns the quote syntax that opens a literal at position i, if any
+func (l *literalLexer) matchQuote(line string, i int) *quoteSyntax {
+	for idx := range l.syntax.quotes {
+		quote := &l.syntax.quotes[idx]
+		if strings.HasPrefix(line[i:], quote.delim) {
+			return quote
+		}
+	}
+	return nil
+}
+
+// hasRawPrefix reports whether the quote at position i has a raw string prefix like r"..."
+func (l *literalLexer) hasRawPrefix(line string, i int) bool {
+	if l.syntax.rawPrefixes == "" {
+		return false
+	}
+	j := i
+	for j > 0 && strings.IndexByte(l.syntax.rawPrefixes, line[j-1]) >= 0 {
+		j--
+	}
+	if j > 0 && isIdentifierChar(line[j-1]) {
+		// Part of a longer identifier, not a prefix
+		return false
+	}
+	return strings.ContainsAny(line[j:i], "rR")
+}
+
+// skipInterpolation returns the position just past the brace that closes an
+// interpolated expression starting at i, or the end of the line
+func skipInterpolation(line string, i int) int {
+	depth := 1
+	for ; i < len(line); i++ {
+		switch line[i] {
+		case '{', '(':
+			depth++
+		case '}', ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(line)
+}
+
+// shellAssignmentValue returns the span of an unquoted value in a shell
+// assignment (VAR=value) whose name starts at position i
+func shellAssignmentValue(line string, i int) (int, int) {
+	if i > 0 && line[i-1] != ' ' && line[i-1] != '\t' && line[i-1] != ';' {
+		return 0, 0
+	}
+	j := i
+	for j < len(line) && isIdentifierChar(line[j]) {
+		j++
+	}
+	if j == i || j >= len(line) || line[j] != '=' || (line[i] >= '0' && line[i] <= '9') {
+		return 0, 0
+	}
+	start := j + 1
+	end := start
+	for end < len(line) && !strings.ContainsRune(" \t;\"'`", rune(line[end])) {
+		end++
+	}
+	return start, end
+}
+
+// isIdentifierChar reports whether c can be part of an identifier
+func isIdentifierChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// literalTokens splits literal values on whitespace, since secrets never contain spaces
+func literalTokens(literals []literal) []literal {
+	var tokens []literal
+	for _, lit := range literals {
+		if !strings.ContainsAny(lit.value, " \t") {
+			tokens = append(tokens, lit)
+			continue
+		}
+		offset := 0
+		for _, field := range strings.Fields(lit.value) {
+			idx := strings.Index(lit.value[offset:], field) + offset
+			start := -1
+			if lit.start >= 0 {
+				start = lit.start + idx
+			}
+			tokens = append(tokens, literal{value: field, start: start, keyPath: lit.keyPath, key: lit.key})
+			offset = idx + len(field)
+		}
+	}
+	return tokens
+}
+
+// textTokens returns the tokenizeLine tokens of free text (a comment, or a line of a
+// file without a lexer) found at offset in the line
+func textTokens(text string, offset int) []literal {
+	var tokens []literal
+	for _, token := range tokenizeLine(text) {
+		start := strings.Index(text, token)
+		if start >= 0 {
+			start += offset
+		}
+		tokens = append(tokens, literal{value: token, start: start})
+	}
+	return tokens
+}
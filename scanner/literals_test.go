@@ -0,0 +1,196 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+func TestLiteralLexer(t *testing.T) {
+	tests := []struct {
+		name     string
+		ext      string
+		lines    []string
+		expected [][]string
+	}{
+		{
+			name:     "go strings and comments",
+			ext:      ".go",
+			lines:    []string{`key := "abc" + 'x' // don't "lex" this`},
+			expected: [][]string{{"abc", "x"}},
+		},
+		{
+			name:     "go raw string across lines",
+			ext:      ".go",
+			lines:    []string{"const tpl = `first", `second "quoted"`, "third` + \"after\""},
+			expected: [][]string{{"first"}, {`second "quoted"`}, {"third", "after"}},
+		},
+		{
+			name:     "go escaped quote",
+			ext:      ".go",
+			lines:    []string{`s := "say \"hi\" now"`},
+			expected: [][]string{{`say \"hi\" now`}},
+		},
+		{
+			name:     "multiple literals in json-like js",
+			ext:      ".js",
+			lines:    []string{`const cfg = {"url": "https://example.com", 'token': 'abc123'};`},
+			expected: [][]string{{"url", "https://example.com", "token", "abc123"}},
+		},
+		{
+			name:     "js template literal with interpolation",
+			ext:      ".ts",
+			lines:    []string{"const h = `Bearer ${token.value} suffix`;"},
+			expected: [][]string{{"Bearer ", " suffix"}},
+		},
+		{
+			name:     "js block comment across lines",
+			ext:      ".js",
+			lines:    []string{`/* "not" a`, `literal */ x = "yes"`},
+			expected: [][]string{nil, {"yes"}},
+		},
+		{
+			name:     "python prefixes and triple quotes",
+			ext:      ".py",
+			lines:    []string{`a = r"C:\path" # "comment"`, `b = """multi`, `line"""`},
+			expected: [][]string{{`C:\path`}, {"multi"}, {"line"}},
+		},
+		{
+			name:     "java text block",
+			ext:      ".java",
+			lines:    []string{`String s = """`, `  secret`, `""";`},
+			expected: [][]string{nil, {"  secret"}, nil},
+		},
+		{
+			name:     "ruby interpolation",
+			ext:      ".rb",
+			lines:    []string{`url = "https://#{host}/api" # 'comment'`},
+			expected: [][]string{{"https://", "/api"}},
+		},
+		{
+			name:     "shell quotes and assignments",
+			ext:      ".sh",
+			lines:    []string{`export TOKEN=abc123 NAME='x y' # comment`, `echo "a#b"`},
+			expected: [][]string{{"abc123", "x y"}, {"a#b"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexer := newLiteralLexer(tt.ext)
+			if lexer == nil {
+				t.Fatalf("newLiteralLexer(%q) returned nil", tt.ext)
+			}
+			for i, line := range tt.lines {
+				var values []string
+				literals, _ := lexer.lexLine(line)
+				for _, lit := range literals {
+					if line[lit.start:lit.start+len(lit.value)] != lit.value {
+						t.Errorf("literal %q has wrong start offset %d in %q", lit.value, lit.start, line)
+					}
+					values = append(values, lit.value)
+				}
+				if !reflect.DeepEqual(values, tt.expected[i]) {
+					t.Errorf("line %d: lexLine(%q) = %q, expected %q", i+1, line, values, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLiteralLexer_Comments(t *testing.T) {
+	lexer := newLiteralLexer(".go")
+	lines := []string{`x := "a" // token: k8Fj2LmQ9zXv`, `/* first`, `last */ y := 1`}
+	expected := [][]string{{"// token: k8Fj2LmQ9zXv"}, {" first"}, {"last "}}
+
+	for i, line := range lines {
+		var values []string
+		_, comments := lexer.lexLine(line)
+		for _, comment := range comments {
+			if line[comment.start:comment.start+len(comment.value)] != comment.value {
+				t.Errorf("comment %q has wrong start offset %d in %q", comment.value, comment.start, line)
+			}
+			values = append(values, comment.value)
+		}
+		if !reflect.DeepEqual(values, expected[i]) {
+			t.Errorf("line %d: lexLine(%q) comments = %q, expected %q", i+1, line, values, expected[i])
+		}
+	}
+}
+
+func TestLiteralTokens(t *testing.T) {
+	literals := []literal{
+		{value: "k8Fj2LmQ9zXv7Rt3Wp5Yb1Nc", start: 5},
+		{value: "Bearer  k8Fj2LmQ9zXv", start: 40},
+	}
+	expected := []literal{
+		{value: "k8Fj2LmQ9zXv7Rt3Wp5Yb1Nc", start: 5},
+		{value: "Bearer", start: 40},
+		{value: "k8Fj2LmQ9zXv", start: 48},
+	}
+
+	if result := literalTokens(literals); !reflect.DeepEqual(result, expected) {
+		t.Errorf("literalTokens() = %+v, expected %+v", result, expected)
+	}
+}
+
+func TestScanner_EntropyOnLiterals(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "client.go")
+	testContent := `package client
+
+var endpoint, key = "https://api.example.com/v1", "k8Fj2LmQ9zXv7Rt3Wp5Yb1NcQ4"
+`
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	scnr, err := NewScanner(&config.Config{EntropyThreshold: 4.5}, []string{})
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	results, err := scnr.ScanFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to scan file: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+	if results[0].Match != MaskMatch("k8Fj2LmQ9zXv7Rt3Wp5Yb1NcQ4", 4) {
+		t.Errorf("Expected the key literal to be reported, got %q", results[0].Match)
+	}
+}
+
+func TestScanner_EntropyInComments(t *testing.T) {
+	tmpDir := t.TempDir()
+	token := "9vQ2mZx7Lw4Kp8Rt3Yb6NcHs1iO9"
+	files := map[string]string{
+		"a.go": "package a\n\n// deploy token: " + token + "\n",
+		"b.py": "# TOKEN=" + token + "\nx = 1\n",
+	}
+
+	scnr, err := NewScanner(&config.Config{EntropyThreshold: 4.0}, []string{})
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			testFile := filepath.Join(tmpDir, name)
+			if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+			results, err := scnr.ScanFile(testFile)
+			if err != nil {
+				t.Fatalf("Failed to scan file: %v", err)
+			}
+			// Commented-out credentials are still reported
+			if len(results) != 1 || results[0].Match != MaskMatch(token, 4) {
+				t.Errorf("ScanFile(%s) = %+v, expected the commented-out token", name, results)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package scanner
+
+import (
+	"fmt"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+// pathSettings are the combined config.PathOverride entries that match a file
+type pathSettings struct {
+	severity      int
+	disabledRules map[string]bool
+	entropy       *bool
+}
+
+// checkPathOverrides checks that the paths section only disables known rules and detectors
+func checkPathOverrides(overrides []config.PathOverride, rules []config.Rule) error {
+	known := knownRuleIDs(rules)
+	for _, override := range overrides {
+		if len(override.Match) == 0 {
+			return fmt.Errorf("invalid paths entry: match needs at least one pattern")
+		}
+		for _, id := range override.DisableRules {
+			if !known[id] {
+				return fmt.Errorf("unknown paths.disable_rules entry %q: no rule or built-in detector has this ID", id)
+			}
+		}
+	}
+	return nil
+}
+
+// pathSettingsFor combines the path overrides that match a relative path, in order
+func (s *Scanner) pathSettingsFor(relPath string) pathSettings {
+	var settings pathSettings
+	for _, override := range s.config.Paths {
+		matched := false
+		for _, pattern := range override.Match {
+			if matchPathPattern(pattern, relPath) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		settings.severity += override.Severity
+		for _, id := range override.DisableRules {
+			if settings.disabledRules == nil {
+				settings.disabledRules = make(map[string]bool)
+			}
+			settings.disabledRules[id] = true
+		}
+		if override.Entropy != nil {
+			settings.entropy = override.Entropy
+		}
+	}
+	return settings
+}
+
+// adjustPathSeverity applies the file's severity adjustment to rule, detector and
+// entropy findings; denylist and honeytoken findings keep their fixed severity
+func (ctx *fileContext) adjustPathSeverity(results []Result) []Result {
+	if ctx.severity == 0 {
+		return results
+	}
+	for i := range results {
+		switch results[i].DetectionType {
+		case "regex", "detector", "entropy":
+			results[i].Severity = adjustSeverity(results[i].Severity, ctx.severity)
+		}
+	}
+	return results
+}
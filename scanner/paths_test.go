@@ -0,0 +1,95 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+func TestScanner_PathOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := "AWS_KEY=AKIAZ7Q4M2XW9LRT5BNC\nAPI_KEY=api_key_k8Fj2LmQ9zXv7Rt3Wp5Yb1\nTOKEN=k8Fj2LmQ9zXv7Rt3Wp5Yb1NcQ4x\n"
+	for _, dir := range []string{"src", "testdata"} {
+		if err := os.MkdirAll(filepath.Join(tmpDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create test directory: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, dir, "app.env"), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	entropyOff := false
+	cfg := &config.Config{
+		EntropyThreshold: 4.0,
+		Rules: []config.Rule{
+			{ID: "aws_access_key", Severity: "high", Pattern: `AKIA[0-9A-Z]{16}`},
+			{ID: "generic_api_key", Severity: "medium", Pattern: `api_key_[0-9A-Za-z]{20}`},
+		},
+		Paths: []config.PathOverride{
+			{Match: []string{"**/testdata/**"}, Severity: -1, DisableRules: []string{"generic_api_key"}, Entropy: &entropyOff},
+		},
+	}
+	scnr, err := NewScanner(cfg, []string{})
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	tests := []struct {
+		dir      string
+		expected []string
+	}{
+		{"src", []string{"aws_access_key:high", "generic_api_key:medium", "entropy:medium"}},
+		{"testdata", []string{"aws_access_key:medium"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			results, err := scnr.ScanFile(filepath.Join(tmpDir, tt.dir, "app.env"))
+			if err != nil {
+				t.Fatalf("Failed to scan file: %v", err)
+			}
+			var found []string
+			for _, result := range results {
+				id := result.RuleID
+				if id == "" {
+					id = result.DetectionType
+				}
+				found = append(found, id+":"+result.Severity)
+			}
+			if len(found) != len(tt.expected) {
+				t.Fatalf("ScanFile(%s/app.env) = %v, expected %v", tt.dir, found, tt.expected)
+			}
+			for i := range found {
+				if found[i] != tt.expected[i] {
+					t.Errorf("ScanFile(%s/app.env) = %v, expected %v", tt.dir, found, tt.expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestCheckPathOverrides(t *testing.T) {
+	rules := []config.Rule{{ID: "aws_access_key"}}
+	tests := []struct {
+		name     string
+		override config.PathOverride
+		valid    bool
+	}{
+		{"rule", config.PathOverride{Match: []string{"*_test.go"}, DisableRules: []string{"aws_access_key"}}, true},
+		{"built-in detector", config.PathOverride{Match: []string{"docs/"}, DisableRules: []string{"jwt", "pii_email"}}, true},
+		{"unknown rule", config.PathOverride{Match: []string{"docs/"}, DisableRules: []string{"stripe"}}, false},
+		{"no patterns", config.PathOverride{Severity: -1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPathOverrides([]config.PathOverride{tt.override}, rules)
+			if (err == nil) != tt.valid {
+				t.Errorf("checkPathOverrides(%+v) error = %v, expected valid = %v", tt.override, err, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package scanner
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ClassificationPII marks findings of personal data rather than credentials
+const ClassificationPII = "pii"
+
+// PIITypes are the supported PII types, in detection order
+var PIITypes = []string{"email", "phone", "ssn", "nino", "iban", "credit_card"}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// phonePattern matches E.164 numbers and North American numbers with separators
+	phonePattern      = regexp.MustCompile(`\+[1-9]\d{7,14}\b|(?:\b|\()\d{3}\)?[-. ]\d{3}[-. ]\d{4}\b`)
+	ssnPattern        = regexp.MustCompile(`\b(\d{3})-(\d{2})-(\d{4})\b`)
+	ninoPattern       = regexp.MustCompile(`\b([A-CEGHJ-PR-TW-Z][A-CEGHJ-NPR-TW-Z]) ?\d{2} ?\d{2} ?\d{2} ?[A-D]\b`)
+	ibanPattern       = regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,4})?\b`)
+	creditCardPattern = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+)
+
+// piiDetectors are the PII detectors by type
+var piiDetectors = map[string]builtinDetector{
+	"email":       {id: "pii_email", severity: "medium", detect: detectEmails, confidence: 0.7},
+	"phone":       {id: "pii_phone", severity: "medium", detect: detectPhoneNumbers, confidence: 0.7},
+	"ssn":         {id: "pii_ssn", severity: "high", detect: detectSSNs, confidence: 0.7},
+	"nino":        {id: "pii_nino", severity: "high", detect: detectNINOs, confidence: 0.7},
+	"iban":        {id: "pii_iban", severity: "high", detect: detectIBANs, confidence: 0.7},
+	"credit_card": {id: "pii_credit_card", severity: "high", detect: detectCreditCards, confidence: 0.7},
+}
+
+// compilePIIDetectors returns the detectors for the enabled PII types, checking
+// that the failing types are known
+func compilePIIDetectors(types, failOn []string) ([]builtinDetector, error) {
+	for _, piiType := range failOn {
+		if _, ok := piiDetectors[piiType]; !ok {
+			return nil, fmt.Errorf("unknown pii.fail_on type %q (expected one of %s)", piiType, strings.Join(PIITypes, ", "))
+		}
+	}
+	if len(types) == 0 {
+		types = PIITypes
+	}
+	var detectors []builtinDetector
+	for _, piiType := range types {
+		detector, ok := piiDetectors[piiType]
+		if !ok {
+			return nil, fmt.Errorf("unknown pii type %q (expected one of %s)", piiType, strings.Join(PIITypes, ", "))
+		}
+		detector.classification = ClassificationPII
+		detector.piiType = piiType
+		detector.tags = []string{"builtin", "pii"}
+		detectors = append(detectors, detector)
+	}
+	return detectors, nil
+}
+
+// piiDetection builds a detection of a PII value at line[start:end]
+func piiDetection(line string, start, end int, description string) detection {
+	value := line[start:end]
+	return detection{
+		start:       start,
+		end:         end,
+		secret:      value,
+		text:        value,
+		match:       MaskMatch(value, 4),
+		description: description,
+	}
+}
+
+// detectEmails finds email addresses, skipping URL userinfo, git SSH remotes and
+// documentation domains
+func detectEmails(line string) []detection {
+	var detections []detection
+	for _, loc := range emailPattern.FindAllStringIndex(line, -1) {
+		if loc[0] > 0 && strings.ContainsRune(":/", rune(line[loc[0]-1])) {
+			continue
+		}
+		email := line[loc[0]:loc[1]]
+		at := strings.Index(email, "@")
+		if email[:at] == "git" || isExampleHost(email[at+1:]) || isReservedDomain(email[at+1:]) {
+			continue
+		}
+		detections = append(detections, piiDetection(line, loc[0], loc[1], "Email address"))
+	}
+	return detections
+}
+
+// isReservedDomain reports whether a domain is under a TLD reserved for testing (RFC 2606)
+func isReservedDomain(domain string) bool {
+	domain = strings.ToLower(domain)
+	for _, tld := range []string{".test", ".invalid", ".local", ".localhost"} {
+		if strings.HasSuffix(domain, tld) {
+			return true
+		}
+	}
+	return false
+}
+
+// detectPhoneNumbers finds international (E.164) and North American phone numbers,
+// skipping the 555-01xx numbers reserved for fiction
+func detectPhoneNumbers(line string) []detection {
+	var detections []detection
+	for _, loc := range phonePattern.FindAllStringIndex(line, -1) {
+		digits := digitsOnly(line[loc[0]:loc[1]])
+		if len(digits) == 10 {
+			if digits[0] == '0' || digits[0] == '1' || (digits[3:6] == "555" && digits[6:8] == "01") {
+				continue
+			}
+		}
+		detections = append(detections, piiDetection(line, loc[0], loc[1], "Phone number"))
+	}
+	return detections
+}
+
+// detectSSNs finds US Social Security numbers in valid ranges
+func detectSSNs(line string) []detection {
+	var detections []detection
+	for _, loc := range ssnPattern.FindAllStringSubmatchIndex(line, -1) {
+		area, group, serial := line[loc[2]:loc[3]], line[loc[4]:loc[5]], line[loc[6]:loc[7]]
+		if area == "000" || area == "666" || area[0] == '9' || group == "00" || serial == "0000" {
+			continue
+		}
+		// Numbers published in advertising and documentation
+		if ssn := line[loc[0]:loc[1]]; ssn == "123-45-6789" || ssn == "078-05-1120" {
+			continue
+		}
+		detections = append(detections, piiDetection(line, loc[0], loc[1], "US Social Security number"))
+	}
+	return detections
+}
+
+// detectNINOs finds UK National Insurance numbers, skipping prefixes that are never issued
+func detectNINOs(line string) []detection {
+	var detections []detection
+	for _, loc := range ninoPattern.FindAllStringSubmatchIndex(line, -1) {
+		switch line[loc[2]:loc[3]] {
+		case "BG", "GB", "NK", "KN", "TN", "NT", "ZZ":
+			continue
+		}
+		detections = append(detections, piiDetection(line, loc[0], loc[1], "UK National Insurance number"))
+	}
+	return detections
+}
+
+// detectIBANs finds IBANs with a valid ISO 13616 checksum
+func detectIBANs(line string) []detection {
+	var detections []detection
+	for _, loc := range ibanPattern.FindAllStringIndex(line, -1) {
+		iban := strings.ReplaceAll(line[loc[0]:loc[1]], " ", "")
+		if len(iban) < 15 || len(iban) > 34 || !validIBANChecksum(iban) {
+			continue
+		}
+		detections = append(detections, piiDetection(line, loc[0], loc[1], "IBAN"))
+	}
+	return detections
+}
+
+// validIBANChecksum reports whether an IBAN (without spaces) satisfies mod 97 = 1
+func validIBANChecksum(iban string) bool {
+	var numeric strings.Builder
+	for _, c := range iban[4:] + iban[:4] {
+		switch {
+		case c >= '0' && c <= '9':
+			numeric.WriteRune(c)
+		case c >= 'A' && c <= 'Z':
+			fmt.Fprintf(&numeric, "%d", c-'A'+10)
+		default:
+			return false
+		}
+	}
+	n, ok := new(big.Int).SetString(numeric.String(), 10)
+	return ok && new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+// detectCreditCards finds payment card numbers with a known issuer prefix and a valid
+// Luhn check digit
+func detectCreditCards(line string) []detection {
+	var detections []detection
+	for _, loc := range creditCardPattern.FindAllStringIndex(line, -1) {
+		digits := digitsOnly(line[loc[0]:loc[1]])
+		if len(digits) < 13 || len(digits) > 19 || !knownCardPrefix(digits) || !luhnValid(digits) {
+			continue
+		}
+		detections = append(detections, piiDetection(line, loc[0], loc[1], "Credit card number"))
+	}
+	return detections
+}
+
+// knownCardPrefix reports whether a card number starts with a major issuer prefix
+// (Visa, Mastercard, American Express, Discover, JCB, Diners Club)
+func knownCardPrefix(digits string) bool {
+	for _, prefix := range []string{"4", "34", "37", "6011", "65", "35", "36", "38", "30"} {
+		if strings.HasPrefix(digits, prefix) {
+			return true
+		}
+	}
+	two, _ := strconv.Atoi(digits[:2])
+	four, _ := strconv.Atoi(digits[:4])
+	return (two >= 51 && two <= 55) || (four >= 2221 && four <= 2720)
+}
+
+// luhnValid reports whether a digit string has a valid Luhn check digit
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// digitsOnly removes everything but digits from s
+func digitsOnly(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
@@ -0,0 +1,94 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+func TestPIIDetectors(t *testing.T) {
+	tests := []struct {
+		name     string
+		detect   func(string) []detection
+		line     string
+		expected []string
+	}{
+		{"email", detectEmails, `"contact": "jane.doe@acme-corp.io"`, []string{"jane.doe@acme-corp.io"}},
+		{"email example domain", detectEmails, `admin@example.com`, nil},
+		{"email git remote", detectEmails, `git@github.com:org/repo.git`, nil},
+		{"email url userinfo", detectEmails, `https://user:pw@host.io`, nil},
+		{"phone e164", detectPhoneNumbers, `phone: +447911123456`, []string{"+447911123456"}},
+		{"phone nanp", detectPhoneNumbers, `call (415) 867-5309 now`, []string{"(415) 867-5309"}},
+		{"phone fictional", detectPhoneNumbers, `415-555-0123`, nil},
+		{"ssn", detectSSNs, `ssn=536-22-8712`, []string{"536-22-8712"}},
+		{"ssn invalid area", detectSSNs, `ssn=666-22-8712`, nil},
+		{"ssn advertising number", detectSSNs, `078-05-1120`, nil},
+		{"nino", detectNINOs, `NI: AB 12 34 56 C`, []string{"AB 12 34 56 C"}},
+		{"nino invalid prefix", detectNINOs, `GB123456A`, nil},
+		{"iban", detectIBANs, `iban: GB82 WEST 1234 5698 7654 32`, []string{"GB82 WEST 1234 5698 7654 32"}},
+		{"iban bad checksum", detectIBANs, `GB83WEST12345698765432`, nil},
+		{"credit card", detectCreditCards, `card 4539 1488 0343 6467`, []string{"4539 1488 0343 6467"}},
+		{"credit card bad luhn", detectCreditCards, `card 4539 1488 0343 6468`, nil},
+		{"credit card unknown issuer", detectCreditCards, `id 9999999999999995`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var found []string
+			for _, d := range tt.detect(tt.line) {
+				found = append(found, tt.line[d.start:d.end])
+			}
+			if len(found) != len(tt.expected) {
+				t.Fatalf("detect(%q) = %q, expected %q", tt.line, found, tt.expected)
+			}
+			for i := range found {
+				if found[i] != tt.expected[i] {
+					t.Errorf("detect(%q) = %q, expected %q", tt.line, found, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestScanner_PII(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "fixture.csv")
+	testContent := "name,email,ssn\nJane,jane.doe@acme-corp.io,536-22-8712\n"
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	scan := func(pii config.PII) []Result {
+		scnr, err := NewScanner(&config.Config{EntropyThreshold: 4.5, PII: pii}, []string{})
+		if err != nil {
+			t.Fatalf("Failed to create scanner: %v", err)
+		}
+		results, err := scnr.ScanFile(testFile)
+		if err != nil {
+			t.Fatalf("Failed to scan file: %v", err)
+		}
+		return results
+	}
+
+	if results := scan(config.PII{}); len(results) != 0 {
+		t.Errorf("Expected no results with PII disabled, got %d", len(results))
+	}
+
+	results := scan(config.PII{Enabled: true, Types: []string{"ssn"}})
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d: %+v", len(results), results)
+	}
+	result := results[0]
+	if result.Classification != ClassificationPII || result.Metadata["pii_type"] != "ssn" || result.RuleID != "pii_ssn" {
+		t.Errorf("Expected an ssn PII result, got %+v", result)
+	}
+	if result.Match != MaskMatch("536-22-8712", 4) {
+		t.Errorf("Expected the SSN to be masked, got %q", result.Match)
+	}
+
+	if _, err := NewScanner(&config.Config{PII: config.PII{Enabled: true, Types: []string{"passport"}}}, []string{}); err == nil {
+		t.Error("Expected an error for an unknown PII type")
+	}
+}
@@ -1,6 +1,11 @@
 package scanner
 
-import "time"
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
 
 // Result represents a detected secret
 type Result struct {
@@ -8,6 +13,16 @@ type Result struct {
 	File string `json:"file"`
 	// Line is the line number where the secret was found (1-indexed)
 	Line int `json:"line"`
+	// StartColumn is the column where the secret starts (1-indexed, in characters)
+	StartColumn int `json:"start_column,omitempty"`
+	// EndColumn is the column just past the end of the secret
+	EndColumn int `json:"end_column,omitempty"`
+	// Offset is the byte offset of the secret from the start of the file
+	Offset int `json:"offset"`
+	// Length is the length of the secret in bytes
+	Length int `json:"length,omitempty"`
+	// KeyPath is the key path of the value in structured files (e.g. spring.datasource.password)
+	KeyPath string `json:"key_path,omitempty"`
 	// RuleID is the identifier of the rule that matched (empty for entropy-based detection)
 	RuleID string `json:"rule_id,omitempty"`
 	// Severity indicates the severity level
@@ -16,10 +31,68 @@ type Result struct {
 	Match string `json:"match"`
 	// Description explains what was detected
 	Description string `json:"description,omitempty"`
-	// DetectionType is either "regex" or "entropy"
+	// DetectionType is "regex", "entropy", "detector" (built-in detectors), "denylist" or "honeytoken"
 	DetectionType string `json:"detection_type"`
+	// Confidence estimates how likely the finding is a real secret, from 0 to 1
+	Confidence float64 `json:"confidence"`
+	// Classification is "canary" for planted honeytokens and "pii" for personal data;
+	// empty for credentials
+	Classification string `json:"classification,omitempty"`
+	// Metadata holds details decoded from the secret, such as JWT claims
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Related are the locations in the same file that satisfied a composite rule's requirements
+	Related []RelatedLocation `json:"related,omitempty"`
 	// ScannedAt is the timestamp when this result was generated
 	ScannedAt time.Time `json:"scanned_at"`
+	// Fingerprint identifies the finding across runs; it is derived from the rule,
+	// the normalized file path and a keyed hash of the secret (not the line number)
+	Fingerprint string `json:"fingerprint"`
+	// Suppression names what silenced this finding (e.g. "inline"); empty for active findings
+	Suppression string `json:"suppression,omitempty"`
+	// SuppressionReason is the justification given for the suppression, if any
+	SuppressionReason string `json:"suppression_reason,omitempty"`
+	// SecretHash is the SHA-256 digest of the detected secret value (never serialized)
+	SecretHash string `json:"-"`
+}
+
+// RelatedLocation is a match paired with a finding, such as the access key ID found
+// near an AWS secret key
+type RelatedLocation struct {
+	// Line is the line number of the match (1-indexed)
+	Line int `json:"line"`
+	// StartColumn is the column where the match starts (1-indexed, in characters)
+	StartColumn int `json:"start_column"`
+	// EndColumn is the column just past the end of the match
+	EndColumn int `json:"end_column"`
+	// Match is the masked matched string
+	Match string `json:"match"`
+}
+
+// defaultFingerprintKey is used when the config does not set fingerprint_key. It is
+// public, so fingerprints made with it are effectively unkeyed.
+const defaultFingerprintKey = "leakyrepo-fingerprint-v1"
+
+// Fingerprint computes a stable finding identifier from a rule ID (or detection
+// type), a slash-separated relative path and the secret value. The secret is hashed
+// with HMAC-SHA256 under key so the fingerprint cannot be matched against plain
+// SHA-256 digests of known secrets. Only a private key stops someone who guesses a
+// secret from confirming it against a published fingerprint.
+func Fingerprint(key, rule, relPath, secret string) string {
+	if key == "" {
+		key = defaultFingerprintKey
+	}
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(secret))
+
+	sum := sha256.Sum256([]byte(rule + "\x00" + relPath + "\x00" + hex.EncodeToString(mac.Sum(nil))))
+	return hex.EncodeToString(sum[:16])
+}
+
+// HashSecret returns the hex-encoded SHA-256 digest of a secret value,
+// as used by allowlist.hashes
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
 }
 
 // MaskMatch masks a sensitive string, showing only first and last few characters
@@ -33,4 +106,3 @@ func MaskMatch(s string, visibleChars int) string {
 	}
 	return s[:visibleChars] + "***" + s[len(s)-visibleChars:]
 }
-
@@ -7,45 +7,259 @@ import (
 	"regexp"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/lgboyce/leakyrepo/config"
 )
 
 // Scanner scans files for secrets using regex rules and entropy detection
 type Scanner struct {
-	config        *config.Config
-	compiledRules []compiledRule
+	config         *config.Config
+	compiledRules  []compiledRule
 	ignorePatterns []string
-	workDir       string
+	workDir        string
+	// root is the directory fingerprints and path settings are relative to
+	root string
+	// allowRegexes and allowLineRegexes are the compiled global allowlist regexes
+	allowRegexes     []*regexp.Regexp
+	allowLineRegexes []*regexp.Regexp
+	// allowHashes holds the SHA-256 digests from allowlist.hashes
+	allowHashes map[string]bool
+	// allowKeyPaths are the compiled allowlist.key_paths patterns
+	allowKeyPaths []*regexp.Regexp
+	// denylist maps the digests of known-leaked secrets to their provider
+	denylist map[string]string
+	// honeytokens are the compiled canary declarations
+	honeytokens []honeytoken
+	// detectors are the built-in detectors, including enabled PII detectors
+	detectors []builtinDetector
+	// benign are the enabled recognizers of tokens that are not secrets
+	benign  []benignRecognizer
+	options Options
+	// suppressed collects findings silenced by inline comments, placeholder detection
+	// or benign token recognizers
+	suppressed []Result
+}
+
+// Options controls scanner behavior that is chosen per run rather than in the config file
+type Options struct {
+	// NoInlineIgnores disables "leakyrepo:allow" comments in scanned files
+	NoInlineIgnores bool
+	// ScanGenerated scans lockfiles, source maps, minified bundles and generated code,
+	// which are skipped or scanned without entropy detection by default
+	ScanGenerated bool
+	// Rules and Tags limit the scan to rules and built-in detectors with these IDs or
+	// tags; empty runs all of them
+	Rules []string
+	Tags  []string
+	// ExcludeRules and ExcludeTags skip rules and built-in detectors with these IDs or tags
+	ExcludeRules []string
+	ExcludeTags  []string
+	// NoEntropy turns off high-entropy detection
+	NoEntropy bool
+	// Root is the directory that fingerprints, rule path allowlists, the paths
+	// section and denylist files are relative to, normally the repository root; empty
+	// uses the working directory
+	Root string
 }
 
 type compiledRule struct {
-	rule    config.Rule
-	pattern *regexp.Regexp
+	rule      config.Rule
+	pattern   *regexp.Regexp
+	allowlist *ruleAllowlist
+	// requires are the compiled requirements of a composite rule
+	requires []requirement
+	// severityOverrides set the severity of matches by their content
+	severityOverrides []severityOverride
+}
+
+// fileContext holds per-file state shared by every line of a scan
+type fileContext struct {
+	path    string
+	relPath string
+	ext     string
+	// rules are the compiled rules that apply to this file
+	rules []compiledRule
+	// lexer extracts string literals for supported languages (nil otherwise)
+	lexer *literalLexer
+	// scalars holds the values of structured files (YAML, JSON, TOML, INI) by line
+	scalars    map[int][]scalarValue
+	structured bool
+	// noEntropy turns off high-entropy detection, for files with a "Code generated ...
+	// DO NOT EDIT" header or by the paths section
+	noEntropy bool
+	// pathSettings are the paths section entries that apply to this file
+	pathSettings
+	// pgpPublicKey is set inside a PGP public key block
+	pgpPublicKey bool
+	// lineOffset is the byte offset of the current line from the start of the file
+	lineOffset int
+}
+
+// setSpan records the position of the secret at line[start:end] on a result
+func (ctx *fileContext) setSpan(result *Result, line string, start, end int) {
+	if start < 0 || end > len(line) || start >= end {
+		return
+	}
+	result.StartColumn = utf8.RuneCountInString(line[:start]) + 1
+	result.EndColumn = result.StartColumn + utf8.RuneCountInString(line[start:end])
+	result.Offset = ctx.lineOffset + start
+	result.Length = end - start
+}
+
+// overlapsAny reports whether any of the results overlaps line[start:end] on the current line
+func (ctx *fileContext) overlapsAny(results []Result, start, end int) bool {
+	for _, result := range results {
+		if ctx.overlaps(result, start, end) {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaps reports whether a result's span overlaps line[start:end] on the current line
+func (ctx *fileContext) overlaps(result Result, start, end int) bool {
+	if result.Length == 0 {
+		return false
+	}
+	resultStart := result.Offset - ctx.lineOffset
+	return start < resultStart+result.Length && resultStart < end
+}
+
+// keyPathFor returns the key path of the structured value on a line that contains secret
+func (ctx *fileContext) keyPathFor(lineNum int, secret string) string {
+	scalars := ctx.scalars[lineNum]
+	for _, scalar := range scalars {
+		if strings.Contains(scalar.value, secret) {
+			return scalar.path
+		}
+	}
+	if len(scalars) == 1 {
+		return scalars[0].path
+	}
+	return ""
 }
 
+// sensitiveKeyEntropyDiscount lowers the entropy threshold for values stored under
+// key names like "password" or "api_key" in structured files
+const sensitiveKeyEntropyDiscount = 1.0
+
 // NewScanner creates a new scanner with the given configuration
 func NewScanner(cfg *config.Config, ignorePatterns []string) (*Scanner, error) {
+	return NewScannerWithOptions(cfg, ignorePatterns, Options{})
+}
+
+// NewScannerWithOptions creates a new scanner with the given configuration and run options
+func NewScannerWithOptions(cfg *config.Config, ignorePatterns []string, opts Options) (*Scanner, error) {
 	workDir, _ := os.Getwd() // Get current working directory for relative path matching
 	scanner := &Scanner{
-		config:        cfg,
-		compiledRules: make([]compiledRule, 0, len(cfg.Rules)),
+		config:         cfg,
+		compiledRules:  make([]compiledRule, 0, len(cfg.Rules)),
 		ignorePatterns: ignorePatterns,
-		workDir:       workDir,
+		workDir:        workDir,
+		root:           workDir,
+		options:        opts,
+	}
+	if opts.Root != "" {
+		scanner.root = opts.Root
+	}
+
+	selection, err := newRuleSelection(opts, cfg.Rules)
+	if err != nil {
+		return nil, err
 	}
 
-	// Compile regex patterns
+	// Compile regex patterns of the selected rules
 	for _, rule := range cfg.Rules {
+		if !selection.selects(rule.ID, rule.Tags) {
+			continue
+		}
 		pattern, err := regexp.Compile(rule.Pattern)
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile pattern for rule %s: %w", rule.ID, err)
 		}
+		allowlist, err := compileRuleAllowlist(rule)
+		if err != nil {
+			return nil, err
+		}
+		requires, err := compileRequirements(rule)
+		if err != nil {
+			return nil, err
+		}
+		overrides, err := compileSeverityOverrides(rule, pattern)
+		if err != nil {
+			return nil, err
+		}
 		scanner.compiledRules = append(scanner.compiledRules, compiledRule{
-			rule:    rule,
-			pattern: pattern,
+			rule:              rule,
+			pattern:           pattern,
+			allowlist:         allowlist,
+			requires:          requires,
+			severityOverrides: overrides,
 		})
 	}
 
+	// Compile allowlist regexes
+	for _, expr := range cfg.Allowlist.Regexes {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowlist.regexes entry %q: %w", expr, err)
+		}
+		scanner.allowRegexes = append(scanner.allowRegexes, re)
+	}
+	for _, expr := range cfg.Allowlist.LineRegexes {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowlist.line_regexes entry %q: %w", expr, err)
+		}
+		scanner.allowLineRegexes = append(scanner.allowLineRegexes, re)
+	}
+	if len(cfg.Allowlist.Hashes) > 0 {
+		scanner.allowHashes = make(map[string]bool, len(cfg.Allowlist.Hashes))
+		for _, hash := range cfg.Allowlist.Hashes {
+			if !isSHA256Hex(hash) {
+				return nil, fmt.Errorf("invalid allowlist.hashes entry %q: expected a hex-encoded SHA-256 digest", hash)
+			}
+			scanner.allowHashes[strings.ToLower(hash)] = true
+		}
+	}
+	for _, pattern := range cfg.Allowlist.KeyPaths {
+		scanner.allowKeyPaths = append(scanner.allowKeyPaths, keyPathRegexp(pattern))
+	}
+	if len(cfg.Denylist.Files) > 0 {
+		denylist, err := loadDenylist(cfg.Denylist.Files, scanner.root)
+		if err != nil {
+			return nil, err
+		}
+		scanner.denylist = denylist
+	}
+	honeytokens, err := compileHoneytokens(cfg.Honeytokens)
+	if err != nil {
+		return nil, err
+	}
+	scanner.honeytokens = honeytokens
+	if err := checkPathOverrides(cfg.Paths, cfg.Rules); err != nil {
+		return nil, err
+	}
+	benign, err := compileBenignRecognizers(cfg.DisableBenignTokens)
+	if err != nil {
+		return nil, err
+	}
+	scanner.benign = benign
+	detectors := builtinDetectors
+	if cfg.PII.Enabled {
+		piiDetectors, err := compilePIIDetectors(cfg.PII.Types, cfg.PII.FailOn)
+		if err != nil {
+			return nil, err
+		}
+		detectors = append(append([]builtinDetector{}, builtinDetectors...), piiDetectors...)
+	}
+	for _, detector := range detectors {
+		if selection.selects(detector.id, detector.tags) {
+			scanner.detectors = append(scanner.detectors, detector)
+		}
+	}
+
 	return scanner, nil
 }
 
@@ -55,22 +269,22 @@ func isBinaryFile(content []byte) bool {
 	if len(content) < 4 {
 		return false
 	}
-	
+
 	// PNG: 89 50 4E 47
 	if len(content) >= 4 && content[0] == 0x89 && content[1] == 0x50 && content[2] == 0x4E && content[3] == 0x47 {
 		return true
 	}
-	
+
 	// JPEG: FF D8 FF
 	if len(content) >= 3 && content[0] == 0xFF && content[1] == 0xD8 && content[2] == 0xFF {
 		return true
 	}
-	
+
 	// GIF: 47 49 46 38
 	if len(content) >= 4 && content[0] == 0x47 && content[1] == 0x49 && content[2] == 0x46 && content[3] == 0x38 {
 		return true
 	}
-	
+
 	// Check if file contains too many null bytes or non-printable characters
 	// If more than 30% of bytes are non-printable (excluding common whitespace), it's likely binary
 	nonPrintableCount := 0
@@ -80,16 +294,16 @@ func isBinaryFile(content []byte) bool {
 			nonPrintableCount++
 		}
 	}
-	
+
 	checkedBytes := len(content)
 	if checkedBytes > 512 {
 		checkedBytes = 512
 	}
-	
+
 	if checkedBytes > 0 && float64(nonPrintableCount)/float64(checkedBytes) > 0.3 {
 		return true
 	}
-	
+
 	return false
 }
 
@@ -113,37 +327,108 @@ func (s *Scanner) ScanFile(filePath string) ([]Result, error) {
 
 	var results []Result
 	lines := strings.Split(string(content), "\n")
-	fileExt := strings.ToLower(filepath.Ext(filePath))
+	ctx := s.newFileContext(filePath, content)
 
 	// Scan each line
 	for lineNum, line := range lines {
-		lineResults := s.scanLine(line, lineNum+1, filePath, fileExt)
+		ctx.trackBlocks(line)
+		lineResults := s.scanLine(line, lineNum+1, ctx)
+		if len(lineResults) > 0 && !s.options.NoInlineIgnores {
+			lineResults = s.applyInlineDirectives(lineResults, lines, lineNum)
+		}
 		results = append(results, lineResults...)
+		ctx.lineOffset += len(line) + 1
 	}
 
-	return results, nil
+	return ctx.adjustPathSeverity(s.resolveComposites(results, lines, ctx)), nil
 }
 
-// scanLine scans a single line for secrets
-func (s *Scanner) scanLine(line string, lineNum int, filePath, fileExt string) []Result {
-	var results []Result
+// suppressPlaceholder records the result as suppressed if its secret looks like a
+// placeholder or example value, and reports whether it did
+func (s *Scanner) suppressPlaceholder(result Result, secret string) bool {
+	if s.config.DisablePlaceholderCheck {
+		return false
+	}
+	reason := PlaceholderReason(secret)
+	if reason == "" {
+		return false
+	}
+	result.Suppression = "placeholder"
+	result.SuppressionReason = reason
+	s.suppressed = append(s.suppressed, result)
+	return true
+}
 
-	// Check against allowlist
-	if s.config.Allowlist.Strings != nil {
-		for _, allowed := range s.config.Allowlist.Strings {
-			if strings.Contains(line, allowed) {
-				return nil // This line is allowlisted
+// Suppressed returns the findings that were silenced by inline comments, placeholder
+// detection or benign token recognizers so far
+func (s *Scanner) Suppressed() []Result {
+	return s.suppressed
+}
+
+// applyInlineDirectives drops results covered by a "leakyrepo:allow" marker on the
+// same line or on a comment-only preceding line and records them as suppressed
+func (s *Scanner) applyInlineDirectives(results []Result, lines []string, index int) []Result {
+	directives := []*inlineDirective{parseInlineDirective(lines[index])}
+	if index > 0 {
+		directives = append(directives, parseStandaloneDirective(lines[index-1]))
+	}
+
+	var kept []Result
+	for _, result := range results {
+		if result.DetectionType == "denylist" {
+			// Known-leaked secrets cannot be suppressed inline
+			kept = append(kept, result)
+			continue
+		}
+		suppressed := false
+		for _, directive := range directives {
+			if directive != nil && directive.covers(result) {
+				result.Suppression = "inline"
+				result.SuppressionReason = directive.reason
+				s.suppressed = append(s.suppressed, result)
+				suppressed = true
+				break
 			}
 		}
+		if !suppressed {
+			kept = append(kept, result)
+		}
+	}
+
+	return kept
+}
+
+// newFileContext resolves the rules that apply to a file and parses structured formats
+func (s *Scanner) newFileContext(filePath string, content []byte) *fileContext {
+	ctx := &fileContext{
+		path:    filePath,
+		relPath: s.relativePath(filePath),
+		ext:     strings.ToLower(filepath.Ext(filePath)),
+	}
+	ctx.lexer = newLiteralLexer(ctx.ext)
+	ctx.pathSettings = s.pathSettingsFor(ctx.relPath)
+	ctx.noEntropy = !s.scanGenerated() && (hasGeneratedHeader(content) || isMinified(content))
+	if ctx.entropy != nil {
+		ctx.noEntropy = !*ctx.entropy
+	}
+	if s.options.NoEntropy {
+		ctx.noEntropy = true
+	}
+
+	if scalars, ok := parseStructured(ctx.ext, content); ok {
+		ctx.structured = true
+		ctx.scalars = make(map[int][]scalarValue)
+		for _, scalar := range scalars {
+			ctx.scalars[scalar.line] = append(ctx.scalars[scalar.line], scalar)
+		}
 	}
 
-	// Apply regex rules
 	for _, compiled := range s.compiledRules {
 		// Check if rule applies to this file type
 		if len(compiled.rule.FileTypes) > 0 {
 			applies := false
 			for _, ft := range compiled.rule.FileTypes {
-				if fileExt == strings.ToLower(ft) {
+				if ctx.ext == strings.ToLower(ft) {
 					applies = true
 					break
 				}
@@ -153,72 +438,208 @@ func (s *Scanner) scanLine(line string, lineNum int, filePath, fileExt string) [
 			}
 		}
 
+		// Check if the rule is allowlisted or disabled for this path
+		if compiled.allowlist.allowsPath(ctx.relPath) || ctx.disabledRules[compiled.rule.ID] {
+			continue
+		}
+
+		ctx.rules = append(ctx.rules, compiled)
+	}
+
+	return ctx
+}
+
+// relativePath returns filePath relative to the scan root, using forward slashes, so
+// fingerprints do not depend on the directory the scan runs from
+func (s *Scanner) relativePath(filePath string) string {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return filepath.ToSlash(filePath)
+	}
+	if relPath, err := filepath.Rel(s.root, absPath); err == nil && !strings.HasPrefix(relPath, "..") {
+		return filepath.ToSlash(relPath)
+	}
+	return filepath.ToSlash(filePath)
+}
+
+// scanLine scans a single line for secrets
+func (s *Scanner) scanLine(line string, lineNum int, ctx *fileContext) []Result {
+	var results []Result
+
+	// Extract entropy candidates first so multi-line lexer state stays in sync
+	// even when the line is allowlisted
+	candidates := s.entropyCandidates(line, lineNum, ctx)
+
+	// Known-leaked secrets and canaries are reported even on allowlisted lines
+	results = s.checkDenylist(line, lineNum, ctx, candidates)
+	results = append(results, s.checkHoneytokens(line, lineNum, ctx, candidates, results)...)
+	reserved := results
+
+	// Check against allowlist
+	if s.config.Allowlist.Strings != nil {
+		for _, allowed := range s.config.Allowlist.Strings {
+			if strings.Contains(line, allowed) {
+				return results // This line is allowlisted
+			}
+		}
+	}
+	for _, re := range s.allowLineRegexes {
+		if re.MatchString(line) {
+			return results
+		}
+	}
+
+	// Apply regex rules
+	for _, compiled := range ctx.rules {
 		// Find all matches
-		matches := compiled.pattern.FindAllString(line, -1)
-		for _, match := range matches {
-			// Check if this match is allowlisted
-			allowlisted := false
-			for _, allowed := range s.config.Allowlist.Strings {
-				if strings.Contains(match, allowed) {
-					allowlisted = true
-					break
-				}
+		matches := compiled.pattern.FindAllStringSubmatchIndex(line, -1)
+		for _, indexes := range matches {
+			match := line[indexes[0]:indexes[1]]
+			start, end := secretSpan(indexes)
+			secret := line[start:end]
+			if ctx.overlapsAny(reserved, start, end) {
+				continue
 			}
-			if allowlisted {
+
+			// Drop matches whose secret is not random enough for this rule
+			if compiled.rule.Entropy > 0 && CalculateShannonEntropy(secret) < compiled.rule.Entropy {
+				continue
+			}
+
+			// Check the rule's own allowlist
+			if compiled.allowlist.allowsSecret(secret) {
+				continue
+			}
+
+			// Check if this match is allowlisted
+			secretHash := HashSecret(secret)
+			keyPath := ctx.keyPathFor(lineNum, secret)
+			if s.isAllowlisted(match, secret, secretHash) || s.isKeyPathAllowlisted(keyPath) {
 				continue
 			}
 
 			maskedMatch := MaskMatch(match, 4)
-			results = append(results, Result{
-				File:          filePath,
+			result := Result{
+				File:          ctx.path,
 				Line:          lineNum,
+				KeyPath:       keyPath,
 				RuleID:        compiled.rule.ID,
-				Severity:      compiled.rule.Severity,
+				Severity:      compiled.severityFor(line, indexes),
 				Match:         maskedMatch,
 				Description:   compiled.rule.Description,
 				DetectionType: "regex",
 				ScannedAt:     time.Now(),
-			})
+				Fingerprint:   Fingerprint(s.config.FingerprintKey, compiled.rule.ID, ctx.relPath, secret),
+				SecretHash:    secretHash,
+			}
+			result.Confidence = ctx.scoreConfidence(regexConfidence(compiled.pattern), secret, lastKey(keyPath), line, start)
+			ctx.setSpan(&result, line, start, end)
+			if s.suppressPlaceholder(result, secret) {
+				continue
+			}
+			results = append(results, result)
+		}
+	}
+
+	// Apply built-in detectors
+	for _, detector := range s.detectors {
+		if ctx.disabledRules[detector.id] {
+			continue
+		}
+		for _, found := range detector.detect(line) {
+			if ctx.overlapsAny(results, found.start, found.end) {
+				continue
+			}
+			secretHash := HashSecret(found.secret)
+			keyPath := ctx.keyPathFor(lineNum, line[found.start:found.end])
+			if s.isAllowlisted(found.text, found.secret, secretHash) || s.isKeyPathAllowlisted(keyPath) {
+				continue
+			}
+
+			result := Result{
+				File:          ctx.path,
+				Line:          lineNum,
+				KeyPath:       keyPath,
+				RuleID:        detector.id,
+				Severity:      detector.severity,
+				Match:         found.match,
+				Description:   found.description,
+				DetectionType: "detector",
+				ScannedAt:     time.Now(),
+				Fingerprint:   Fingerprint(s.config.FingerprintKey, detector.id, ctx.relPath, found.secret),
+				SecretHash:    secretHash,
+			}
+			if found.severity != "" {
+				result.Severity = found.severity
+			}
+			if len(found.metadata) > 0 {
+				result.Metadata = found.metadata
+			}
+			if detector.classification != "" {
+				result.Classification = detector.classification
+			}
+			if detector.piiType != "" {
+				result.Metadata = map[string]string{"pii_type": detector.piiType}
+			}
+			result.Confidence = ctx.scoreConfidence(detector.confidence, found.secret, lastKey(keyPath), line, found.start)
+			ctx.setSpan(&result, line, found.start, found.end)
+			if s.suppressPlaceholder(result, found.secret) {
+				continue
+			}
+			results = append(results, result)
 		}
 	}
 
 	// Check for high-entropy strings
-	// Split line by common delimiters and check each token
-	tokens := tokenizeLine(line)
-	for _, token := range tokens {
+	// Use string literals for supported languages, otherwise split the line by common delimiters
+	if ctx.noEntropy {
+		return results
+	}
+	for _, candidate := range candidates {
+		token := candidate.value
+		threshold := s.config.EntropyThreshold
+		description := "High-entropy string detected (possible secret)"
+		if isSensitiveKey(candidate.key) {
+			// The key name is a strong signal, so accept less random values
+			threshold -= sensitiveKeyEntropyDiscount
+			description = fmt.Sprintf("High-entropy value for sensitive key %q", candidate.key)
+		}
+
 		// Minimum length must match IsHighEntropy requirement (16 chars)
-		if len(token) >= 16 && IsHighEntropy(token, s.config.EntropyThreshold) {
+		if len(token) >= 16 && IsHighEntropy(token, threshold) {
 			// Check if token is allowlisted
-			allowlisted := false
-			for _, allowed := range s.config.Allowlist.Strings {
-				if strings.Contains(token, allowed) {
-					allowlisted = true
-					break
-				}
-			}
-			if allowlisted {
+			tokenHash := HashSecret(token)
+			if s.isAllowlisted(token, token, tokenHash) || s.isKeyPathAllowlisted(candidate.keyPath) {
 				continue
 			}
 
-			// Check if this high-entropy string was already matched by a regex rule
-			alreadyMatched := false
-			for _, result := range results {
-				if result.Line == lineNum && strings.Contains(result.Match, token) {
-					alreadyMatched = true
-					break
-				}
+			start := candidate.start
+			if start < 0 {
+				start = strings.Index(line, token)
 			}
-			if !alreadyMatched {
+			end := start + len(token)
+
+			// Check if this high-entropy string was already matched by a rule or detector
+			if start < 0 || !ctx.overlapsAny(results, start, end) {
 				maskedMatch := MaskMatch(token, 4)
-				results = append(results, Result{
-					File:          filePath,
+				result := Result{
+					File:          ctx.path,
 					Line:          lineNum,
+					KeyPath:       candidate.keyPath,
 					Severity:      "medium",
 					Match:         maskedMatch,
-					Description:   "High-entropy string detected (possible secret)",
+					Description:   description,
 					DetectionType: "entropy",
 					ScannedAt:     time.Now(),
-				})
+					Fingerprint:   Fingerprint(s.config.FingerprintKey, "entropy", ctx.relPath, token),
+					SecretHash:    tokenHash,
+				}
+				result.Confidence = ctx.scoreConfidence(entropyConfidence(token, threshold), token, candidate.key, line, start)
+				ctx.setSpan(&result, line, start, end)
+				if s.suppressBenign(result, ctx, line, token, start) || s.suppressPlaceholder(result, token) {
+					continue
+				}
+				results = append(results, result)
 			}
 		}
 	}
@@ -226,11 +647,52 @@ func (s *Scanner) scanLine(line string, lineNum int, filePath, fileExt string) [
 	return results
 }
 
+// secretSpan returns the span of the secret portion of a regex match, given the
+// submatch indexes: the last non-empty capture group, or the whole match when the
+// pattern has no groups
+func secretSpan(indexes []int) (int, int) {
+	for i := len(indexes)/2 - 1; i > 0; i-- {
+		if start, end := indexes[2*i], indexes[2*i+1]; start >= 0 && end > start {
+			return start, end
+		}
+	}
+	return indexes[0], indexes[1]
+}
+
+// entropyCandidates returns the values on a line to check for high entropy: the
+// scalar values and comment tokens of structured files, the string literals and
+// comment tokens for languages with a lexer, otherwise the tokens of tokenizeLine
+func (s *Scanner) entropyCandidates(line string, lineNum int, ctx *fileContext) []literal {
+	if ctx.structured {
+		var values []literal
+		for _, scalar := range ctx.scalars[lineNum] {
+			values = append(values, literal{value: scalar.value, start: strings.Index(line, scalar.value), keyPath: scalar.path, key: scalar.key})
+		}
+		candidates := literalTokens(values)
+		// Comments are not part of the parsed document, so tokenize them as text
+		if start := structuredComment(line, ctx.ext, values); start >= 0 {
+			candidates = append(candidates, textTokens(line[start:], start)...)
+		}
+		return candidates
+	}
+	if ctx.lexer != nil {
+		literals, comments := ctx.lexer.lexLine(line)
+		candidates := literalTokens(literals)
+		// Commented-out credentials are checked like any other text
+		for _, comment := range comments {
+			candidates = append(candidates, textTokens(comment.value, comment.start)...)
+		}
+		return candidates
+	}
+
+	return textTokens(line, 0)
+}
+
 // tokenizeLine splits a line into potential secret tokens
 // Focuses on common secret patterns (key=value, key:value) rather than aggressive splitting
 func tokenizeLine(line string) []string {
 	var tokens []string
-	
+
 	// First, try to extract values from common key-value patterns
 	// Pattern: key=value or key:value (common in .env, config files)
 	kvPatterns := []string{"=", ":", " = ", " : "}
@@ -248,13 +710,13 @@ func tokenizeLine(line string) []string {
 			}
 		}
 	}
-	
+
 	// If no key-value patterns found, split by whitespace and common delimiters
 	// but only for longer tokens to reduce false positives
 	if len(tokens) == 0 {
 		delimiters := []string{" ", "\t", ",", ";", "|"}
 		currentTokens := []string{line}
-		
+
 		for _, delim := range delimiters {
 			var newTokens []string
 			for _, token := range currentTokens {
@@ -262,8 +724,8 @@ func tokenizeLine(line string) []string {
 				for _, part := range parts {
 					part = strings.TrimSpace(part)
 					// Only keep tokens that are long enough and don't look like code
-					if len(part) >= 16 && !strings.Contains(part, "${") && 
-					   !strings.HasPrefix(part, "<") && !strings.Contains(part, "[") {
+					if len(part) >= 16 && !strings.Contains(part, "${") &&
+						!strings.HasPrefix(part, "<") && !strings.Contains(part, "[") {
 						newTokens = append(newTokens, part)
 					}
 				}
@@ -278,6 +740,11 @@ func tokenizeLine(line string) []string {
 
 // shouldIgnoreFile checks if a file should be ignored based on patterns
 func (s *Scanner) shouldIgnoreFile(filePath string) bool {
+	// Skip lockfiles, source maps and minified bundles
+	if !s.scanGenerated() && generatedFileKind(filePath) != "" {
+		return true
+	}
+
 	// Check config allowlist
 	for _, pattern := range s.config.Allowlist.Files {
 		if matched, _ := filepath.Match(pattern, filePath); matched {
@@ -354,4 +821,3 @@ func (s *Scanner) shouldIgnoreFile(filePath string) bool {
 
 	return false
 }
-
@@ -0,0 +1,86 @@
+package scanner
+
+import (
+	"fmt"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+// ruleSelection picks the rules and built-in detectors that run, by ID and tag.
+// With no include filters every rule is selected; exclusions always win.
+type ruleSelection struct {
+	rules        map[string]bool
+	tags         map[string]bool
+	excludeRules map[string]bool
+	excludeTags  map[string]bool
+}
+
+// newRuleSelection builds the selection from the run options, checking that the
+// rule IDs exist in the config or are built-in detectors
+func newRuleSelection(opts Options, rules []config.Rule) (ruleSelection, error) {
+	known := knownRuleIDs(rules)
+	for _, ids := range [][]string{opts.Rules, opts.ExcludeRules} {
+		for _, id := range ids {
+			if !known[id] {
+				return ruleSelection{}, fmt.Errorf("unknown rule %q: no rule or built-in detector has this ID", id)
+			}
+		}
+	}
+	return ruleSelection{
+		rules:        stringSet(opts.Rules),
+		tags:         stringSet(opts.Tags),
+		excludeRules: stringSet(opts.ExcludeRules),
+		excludeTags:  stringSet(opts.ExcludeTags),
+	}, nil
+}
+
+// selects reports whether a rule or detector with the given ID and tags runs
+func (sel ruleSelection) selects(id string, tags []string) bool {
+	if sel.excludeRules[id] {
+		return false
+	}
+	for _, tag := range tags {
+		if sel.excludeTags[tag] {
+			return false
+		}
+	}
+	if len(sel.rules) == 0 && len(sel.tags) == 0 {
+		return true
+	}
+	if sel.rules[id] {
+		return true
+	}
+	for _, tag := range tags {
+		if sel.tags[tag] {
+			return true
+		}
+	}
+	return false
+}
+
+// knownRuleIDs returns the IDs of the configured rules and of all built-in detectors
+func knownRuleIDs(rules []config.Rule) map[string]bool {
+	known := make(map[string]bool)
+	for _, rule := range rules {
+		known[rule.ID] = true
+	}
+	for _, detector := range builtinDetectors {
+		known[detector.id] = true
+	}
+	for _, detector := range piiDetectors {
+		known[detector.id] = true
+	}
+	return known
+}
+
+// stringSet returns the values as a set (nil for no values)
+func stringSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
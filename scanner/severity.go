@@ -0,0 +1,92 @@
+package scanner
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+// severityLevels are the severities in increasing order
+var severityLevels = []string{"low", "medium", "high", "critical"}
+
+// severityOverride is a compiled config.SeverityOverride
+type severityOverride struct {
+	pattern *regexp.Regexp
+	// group is the index of the capture group to match (0 for the whole match)
+	group    int
+	severity string
+}
+
+// IsSeverity reports whether severity is a known severity level
+func IsSeverity(severity string) bool {
+	return severityRank(severity) >= 0
+}
+
+// MeetsSeverity reports whether severity is at or above threshold. Unknown
+// severities meet every threshold, so custom levels never pass silently.
+func MeetsSeverity(severity, threshold string) bool {
+	rank := severityRank(severity)
+	return rank < 0 || rank >= severityRank(threshold)
+}
+
+// severityRank returns the position of severity in severityLevels, or -1
+func severityRank(severity string) int {
+	for i, level := range severityLevels {
+		if level == severity {
+			return i
+		}
+	}
+	return -1
+}
+
+// raiseSeverity returns the next severity level above severity, capped at critical
+func raiseSeverity(severity string) string {
+	return adjustSeverity(severity, 1)
+}
+
+// adjustSeverity moves severity by levels (negative to lower it), staying within
+// low and critical
+func adjustSeverity(severity string, levels int) string {
+	rank := severityRank(severity)
+	if rank < 0 {
+		return severity
+	}
+	return severityLevels[max(0, min(rank+levels, len(severityLevels)-1))]
+}
+
+// compileSeverityOverrides compiles the severity overrides of a rule, checking that
+// named groups exist in the rule pattern
+func compileSeverityOverrides(rule config.Rule, pattern *regexp.Regexp) ([]severityOverride, error) {
+	var overrides []severityOverride
+	for _, override := range rule.SeverityOverrides {
+		if !IsSeverity(override.Severity) {
+			return nil, fmt.Errorf("invalid severity_overrides severity %q for rule %s (expected one of %s)", override.Severity, rule.ID, strings.Join(severityLevels, ", "))
+		}
+		re, err := regexp.Compile(override.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid severity_overrides pattern %q for rule %s: %w", override.Pattern, rule.ID, err)
+		}
+		compiled := severityOverride{pattern: re, severity: override.Severity}
+		if override.Group != "" {
+			if compiled.group = pattern.SubexpIndex(override.Group); compiled.group < 0 {
+				return nil, fmt.Errorf("invalid severity_overrides group %q for rule %s: no such named group in the rule pattern", override.Group, rule.ID)
+			}
+		}
+		overrides = append(overrides, compiled)
+	}
+	return overrides, nil
+}
+
+// severityFor returns the severity of a match of the rule on line, given the
+// submatch indexes of the match
+func (c *compiledRule) severityFor(line string, indexes []int) string {
+	for _, override := range c.severityOverrides {
+		start, end := indexes[2*override.group], indexes[2*override.group+1]
+		if start >= 0 && override.pattern.MatchString(line[start:end]) {
+			return override.severity
+		}
+	}
+	return c.rule.Severity
+}
@@ -0,0 +1,404 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// scalarValue is a scalar value from a structured config file
+type scalarValue struct {
+	// path is the key path of the value (spring.datasource.password, $.credentials[0].token)
+	path string
+	// key is the name of the innermost key
+	key   string
+	value string
+	// line is the 1-indexed line where the value appears
+	line int
+}
+
+// sensitiveKeyPattern matches key names that usually hold credentials
+var sensitiveKeyPattern = regexp.MustCompile(`(?i)(passw(or)?d|passwd|pwd|secret|token|api[_-]?key|apikey|credential|private[_-]?key|access[_-]?key|auth)`)
+
+// isSensitiveKey reports whether a key name suggests its value is a credential
+func isSensitiveKey(key string) bool {
+	return key != "" && sensitiveKeyPattern.MatchString(key)
+}
+
+// parseStructured extracts the scalar values of YAML, JSON, TOML and INI files.
+// It returns false if the extension is not a structured format or the file cannot be parsed.
+func parseStructured(ext string, content []byte) ([]scalarValue, bool) {
+	switch ext {
+	case ".yaml", ".yml":
+		return parseYAML(content)
+	case ".json":
+		return parseJSON(content)
+	case ".toml":
+		return parseTOML(string(content)), true
+	case ".ini", ".cfg":
+		return parseINI(string(content)), true
+	}
+	return nil, false
+}
+
+// parseYAML walks every document of a YAML file
+func parseYAML(content []byte) ([]scalarValue, bool) {
+	var values []scalarValue
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var doc yaml.Node
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, false
+		}
+		walkYAML(&doc, "", "", &values)
+	}
+	return values, true
+}
+
+// walkYAML collects the scalars below a YAML node
+func walkYAML(node *yaml.Node, path, key string, values *[]scalarValue) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			walkYAML(child, path, key, values)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name := node.Content[i].Value
+			walkYAML(node.Content[i+1], joinKeyPath(path, name), name, values)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			walkYAML(child, fmt.Sprintf("%s[%d]", path, i), key, values)
+		}
+	case yaml.ScalarNode:
+		if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+			*values = append(*values, scalarValue{path: path, key: key, value: node.Value, line: node.Line})
+			return
+		}
+		// Block scalars start on the line after the key
+		for i, text := range strings.Split(strings.TrimRight(node.Value, "\n"), "\n") {
+			if text = strings.TrimSpace(text); text != "" {
+				*values = append(*values, scalarValue{path: path, key: key, value: text, line: node.Line + 1 + i})
+			}
+		}
+	}
+}
+
+// parseJSON streams the tokens of a JSON file, tracking key paths and line numbers
+func parseJSON(content []byte) ([]scalarValue, bool) {
+	type frame struct {
+		path      string
+		key       string
+		array     bool
+		index     int
+		expectKey bool
+	}
+
+	lineStarts := []int{0}
+	for i, b := range content {
+		if b == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	lineAt := func(offset int64) int {
+		return sort.Search(len(lineStarts), func(i int) bool { return int64(lineStarts[i]) > offset-1 })
+	}
+
+	var values []scalarValue
+	var stack []*frame
+	pendingKey := ""
+
+	// valuePath returns the path and key of the value about to be read
+	valuePath := func() (string, string) {
+		if len(stack) == 0 {
+			return "$", ""
+		}
+		top := stack[len(stack)-1]
+		if top.array {
+			return fmt.Sprintf("%s[%d]", top.path, top.index), top.key
+		}
+		return joinKeyPath(top.path, pendingKey), pendingKey
+	}
+	// valueDone advances the enclosing container after a value has been read
+	valueDone := func() {
+		if len(stack) == 0 {
+			return
+		}
+		top := stack[len(stack)-1]
+		if top.array {
+			top.index++
+		} else {
+			top.expectKey = true
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, false
+		}
+
+		switch t := token.(type) {
+		case json.Delim:
+			switch t {
+			case '{', '[':
+				path, key := valuePath()
+				stack = append(stack, &frame{path: path, key: key, array: t == '[', expectKey: t == '{'})
+			case '}', ']':
+				stack = stack[:len(stack)-1]
+				valueDone()
+			}
+		default:
+			if len(stack) > 0 && stack[len(stack)-1].expectKey {
+				pendingKey = fmt.Sprint(t)
+				stack[len(stack)-1].expectKey = false
+				continue
+			}
+			path, key := valuePath()
+			if t != nil {
+				values = append(values, scalarValue{path: path, key: key, value: fmt.Sprint(t), line: lineAt(decoder.InputOffset())})
+			}
+			valueDone()
+		}
+	}
+	if len(stack) > 0 {
+		// Truncated document
+		return nil, false
+	}
+
+	return values, true
+}
+
+// parseTOML extracts key/value pairs from a TOML file, including tables, arrays of
+// tables, inline arrays and tables, and multi-line strings
+func parseTOML(content string) []scalarValue {
+	var values []scalarValue
+	table := ""
+	arrayCounts := make(map[string]int)
+
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "[[") {
+			name := tomlKeyPath(strings.Trim(stripTOMLComment(trimmed), "[] \t"))
+			table = fmt.Sprintf("%s[%d]", name, arrayCounts[name])
+			arrayCounts[name]++
+			continue
+		}
+		if strings.HasPrefix(trimmed, "[") {
+			table = tomlKeyPath(strings.Trim(stripTOMLComment(trimmed), "[] \t"))
+			continue
+		}
+
+		eq := indexOutsideQuotes(trimmed, '=')
+		if eq < 0 {
+			continue
+		}
+		key := tomlKeyPath(trimmed[:eq])
+		path := joinKeyPath(table, key)
+		rest := strings.TrimSpace(trimmed[eq+1:])
+
+		// Multi-line strings continue until the closing delimiter
+		if strings.HasPrefix(rest, `"""`) || strings.HasPrefix(rest, "'''") {
+			delim := rest[:3]
+			text := rest[3:]
+			for lineNum := i + 1; ; {
+				end := strings.Index(text, delim)
+				if end >= 0 {
+					text = text[:end]
+				}
+				if text = strings.TrimSpace(text); text != "" {
+					values = append(values, scalarValue{path: path, key: lastKey(key), value: text, line: lineNum})
+				}
+				if end >= 0 || i+1 >= len(lines) {
+					break
+				}
+				i++
+				lineNum = i + 1
+				text = lines[i]
+			}
+			continue
+		}
+
+		values = append(values, tomlValues(path, lastKey(key), rest, i+1)...)
+	}
+
+	return values
+}
+
+// tomlValues parses a single-line TOML value, expanding inline arrays and tables
+func tomlValues(path, key, raw string, line int) []scalarValue {
+	raw = strings.TrimSpace(raw)
+	switch {
+	case strings.HasPrefix(raw, "["):
+		var values []scalarValue
+		inner := strings.TrimSuffix(strings.TrimSpace(stripTOMLComment(raw)), "]")
+		for i, item := range splitOutsideQuotes(strings.TrimPrefix(inner, "["), ',') {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, tomlValues(fmt.Sprintf("%s[%d]", path, i), key, item, line)...)
+			}
+		}
+		return values
+	case strings.HasPrefix(raw, "{"):
+		var values []scalarValue
+		inner := strings.TrimSuffix(strings.TrimSpace(stripTOMLComment(raw)), "}")
+		for _, pair := range splitOutsideQuotes(strings.TrimPrefix(inner, "{"), ',') {
+			eq := indexOutsideQuotes(pair, '=')
+			if eq < 0 {
+				continue
+			}
+			subKey := tomlKeyPath(pair[:eq])
+			values = append(values, tomlValues(joinKeyPath(path, subKey), lastKey(subKey), pair[eq+1:], line)...)
+		}
+		return values
+	}
+	return []scalarValue{{path: path, key: key, value: unquoteValue(stripTOMLComment(raw)), line: line}}
+}
+
+// parseINI extracts key/value pairs from an INI file as section.key paths
+func parseINI(content string) []scalarValue {
+	var values []scalarValue
+	section := ""
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, ";") || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			section = strings.TrimSpace(trimmed[1 : len(trimmed)-1])
+			continue
+		}
+
+		sep := strings.IndexAny(trimmed, "=:")
+		if sep < 0 {
+			continue
+		}
+		key := strings.TrimSpace(trimmed[:sep])
+		value := strings.TrimSpace(trimmed[sep+1:])
+		for _, marker := range []string{" ;", " #"} {
+			if idx := strings.Index(value, marker); idx >= 0 {
+				value = strings.TrimSpace(value[:idx])
+			}
+		}
+		values = append(values, scalarValue{path: joinKeyPath(section, key), key: key, value: unquoteValue(value), line: i + 1})
+	}
+	return values
+}
+
+// joinKeyPath appends a key to a dotted key path
+func joinKeyPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// lastKey returns the innermost key of a dotted key path
+func lastKey(path string) string {
+	return path[strings.LastIndex(path, ".")+1:]
+}
+
+// tomlKeyPath normalizes a (possibly quoted, dotted) TOML key
+func tomlKeyPath(raw string) string {
+	var parts []string
+	for _, part := range splitOutsideQuotes(raw, '.') {
+		parts = append(parts, unquoteValue(strings.TrimSpace(part)))
+	}
+	return strings.Join(parts, ".")
+}
+
+// stripTOMLComment removes a trailing # comment outside of quotes
+func stripTOMLComment(s string) string {
+	if idx := indexOutsideQuotes(s, '#'); idx >= 0 {
+		return strings.TrimSpace(s[:idx])
+	}
+	return s
+}
+
+// unquoteValue removes matching surrounding quotes
+func unquoteValue(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// indexOutsideQuotes returns the index of the first sep that is not inside quotes
+func indexOutsideQuotes(s string, sep byte) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == sep:
+			return i
+		}
+	}
+	return -1
+}
+
+// splitOutsideQuotes splits s on every sep that is not inside quotes
+func splitOutsideQuotes(s string, sep byte) []string {
+	var parts []string
+	for {
+		idx := indexOutsideQuotes(s, sep)
+		if idx < 0 {
+			return append(parts, s)
+		}
+		parts = append(parts, s[:idx])
+		s = s[idx+1:]
+	}
+}
+
+// structuredComment returns the offset of the comment on a line of a YAML, TOML or
+// INI file, or -1. A comment marker starts a line or follows whitespace, and is not
+// inside one of the parsed values on the line.
+func structuredComment(line, ext string, values []literal) int {
+	markers := "#"
+	if ext == ".ini" || ext == ".cfg" {
+		markers = "#;"
+	}
+	for i := 0; i < len(line); i++ {
+		if strings.IndexByte(markers, line[i]) < 0 || (i > 0 && line[i-1] != ' ' && line[i-1] != '\t') {
+			continue
+		}
+		inValue := false
+		for _, value := range values {
+			if value.start >= 0 && i >= value.start && i < value.start+len(value.value) {
+				inValue = true
+				break
+			}
+		}
+		if !inValue {
+			return i
+		}
+	}
+	return -1
+}
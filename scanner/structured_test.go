@@ -0,0 +1,219 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/lgboyce/leakyrepo/config"
+)
+
+func TestParseStructured(t *testing.T) {
+	tests := []struct {
+		name     string
+		ext      string
+		content  string
+		expected []scalarValue
+	}{
+		{
+			name: "yaml nested mapping and sequence",
+			ext:  ".yml",
+			content: `spring:
+  datasource:
+    password: hunter2
+servers:
+  - host: a.example.com
+`,
+			expected: []scalarValue{
+				{path: "spring.datasource.password", key: "password", value: "hunter2", line: 3},
+				{path: "servers[0].host", key: "host", value: "a.example.com", line: 5},
+			},
+		},
+		{
+			name: "yaml block scalar",
+			ext:  ".yaml",
+			content: `cert: |
+  line-one
+  line-two
+`,
+			expected: []scalarValue{
+				{path: "cert", key: "cert", value: "line-one", line: 2},
+				{path: "cert", key: "cert", value: "line-two", line: 3},
+			},
+		},
+		{
+			name: "json objects and arrays",
+			ext:  ".json",
+			content: `{
+  "credentials": [
+    {"token": "abc"}
+  ],
+  "port": 8080
+}`,
+			expected: []scalarValue{
+				{path: "$.credentials[0].token", key: "token", value: "abc", line: 3},
+				{path: "$.port", key: "port", value: "8080", line: 5},
+			},
+		},
+		{
+			name: "toml tables and inline values",
+			ext:  ".toml",
+			content: `title = "app" # comment
+[database]
+password = 'hunter2'
+[[users]]
+keys = ["a", "b"]
+`,
+			expected: []scalarValue{
+				{path: "title", key: "title", value: "app", line: 1},
+				{path: "database.password", key: "password", value: "hunter2", line: 3},
+				{path: "users[0].keys[0]", key: "keys", value: "a", line: 5},
+				{path: "users[0].keys[1]", key: "keys", value: "b", line: 5},
+			},
+		},
+		{
+			name: "ini sections",
+			ext:  ".ini",
+			content: `; comment
+[smtp]
+user = mailer
+pass = "hunter2" ; trailing
+`,
+			expected: []scalarValue{
+				{path: "smtp.user", key: "user", value: "mailer", line: 3},
+				{path: "smtp.pass", key: "pass", value: "hunter2", line: 4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, ok := parseStructured(tt.ext, []byte(tt.content))
+			if !ok {
+				t.Fatalf("parseStructured(%q) failed to parse", tt.ext)
+			}
+			if !reflect.DeepEqual(values, tt.expected) {
+				t.Errorf("parseStructured(%q) = %+v, expected %+v", tt.ext, values, tt.expected)
+			}
+		})
+	}
+
+	if _, ok := parseStructured(".json", []byte(`{"broken": `)); ok {
+		t.Errorf("parseStructured should fail on invalid JSON")
+	}
+	if _, ok := parseStructured(".go", []byte(`package main`)); ok {
+		t.Errorf("parseStructured should not handle .go files")
+	}
+}
+
+func TestKeyPathRegexp(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		keyPath  string
+		expected bool
+	}{
+		{"spring.datasource.password", "spring.datasource.password", true},
+		{"spring.*.password", "spring.datasource.password", true},
+		{"spring.*.password", "spring.a.b.password", false},
+		{"spring.**.password", "spring.a.b.password", true},
+		{"$.credentials[0].token", "$.credentials[0].token", true},
+		{"credentials[0].token", "$.credentials[0].token", true},
+		{"tests.*", "tests.fixtures.key", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.keyPath, func(t *testing.T) {
+			result := keyPathRegexp(tt.pattern).MatchString(trimKeyPathRoot(tt.keyPath))
+			if result != tt.expected {
+				t.Errorf("keyPathRegexp(%q) on %q = %v, expected %v", tt.pattern, tt.keyPath, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScanner_StructuredKeyPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "application.yml")
+	testContent := `spring:
+  datasource:
+    url: jdbc:postgresql://db.internal:5432/app
+    password: Tq8vLm2XwR7pZk4N
+test:
+  fixture_key: k8Fj2LmQ9zXv7Rt3Wp5Yb1NcQ4
+`
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cfg := &config.Config{
+		EntropyThreshold: 4.5,
+		Allowlist:        config.Allowlist{KeyPaths: []string{"test.*"}},
+	}
+	scnr, err := NewScanner(cfg, []string{})
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	results, err := scnr.ScanFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to scan file: %v", err)
+	}
+
+	// The password is below the global threshold but sits under a sensitive key;
+	// the fixture key is allowlisted by its key path
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d: %+v", len(results), results)
+	}
+	if results[0].KeyPath != "spring.datasource.password" {
+		t.Errorf("Expected key path spring.datasource.password, got %q", results[0].KeyPath)
+	}
+	if results[0].Line != 4 {
+		t.Errorf("Expected line 4, got %d", results[0].Line)
+	}
+}
+
+func TestStructuredComment(t *testing.T) {
+	tests := []struct {
+		line     string
+		ext      string
+		values   []literal
+		expected int
+	}{
+		{"# old key: 9vQ2mZx7Lw4Kp8Rt3Yb6NcHs1iO9", ".yml", nil, 0},
+		{"key: value # rotated", ".yml", []literal{{value: "value", start: 5}}, 11},
+		{"url: http://host/#anchor", ".yml", []literal{{value: "http://host/#anchor", start: 5}}, -1},
+		{"color: '#fff'", ".yml", []literal{{value: "#fff", start: 8}}, -1},
+		{"; old password", ".ini", nil, 0},
+		{"; not a comment", ".yml", nil, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			if got := structuredComment(tt.line, tt.ext, tt.values); got != tt.expected {
+				t.Errorf("structuredComment(%q, %q) = %d, expected %d", tt.line, tt.ext, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScanner_EntropyInStructuredComments(t *testing.T) {
+	token := "9vQ2mZx7Lw4Kp8Rt3Yb6NcHs1iO9"
+	testFile := filepath.Join(t.TempDir(), "c.yml")
+	testContent := "service:\n  # old key: " + token + "\n  name: api\n"
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	scnr, err := NewScanner(&config.Config{EntropyThreshold: 4.0}, []string{})
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	results, err := scnr.ScanFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to scan file: %v", err)
+	}
+	// Comments are outside the parsed document but still checked
+	if len(results) != 1 || results[0].Line != 2 || results[0].Match != MaskMatch(token, 4) {
+		t.Errorf("ScanFile(c.yml) = %+v, expected the commented-out token on line 2", results)
+	}
+}